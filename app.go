@@ -1,11 +1,21 @@
 package ponodo
 
 import (
+	"database/sql"
+	"fmt"
+	"os"
+
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 	clicontracts "github.com/zerpto/ponodo/cli/contracts"
+	"github.com/zerpto/ponodo/container"
 	"github.com/zerpto/ponodo/contracts"
+	"github.com/zerpto/ponodo/metrics"
+	"github.com/zerpto/ponodo/migration"
 
 	//"github.com/zerpto/template-backend-go/src/routers"
 	"gorm.io/gorm"
@@ -23,6 +33,16 @@ type App struct {
 	Command      *cobra.Command
 	Gin          *gin.Engine
 	Validator    *validator.Validate
+	Container    *container.Container
+	Providers    []contracts.ServiceProviderContract
+	Connections  map[string]*gorm.DB
+	Migrations   []migration.Migration
+	Seeders      []contracts.SeederContract
+	HealthChecks []contracts.HealthCheckContract
+	Metrics      *prometheus.Registry
+	Logger       *zerolog.Logger
+
+	started []contracts.ServiceProviderContract
 }
 
 // SetConfigLoader sets the configuration loader instance for the application.
@@ -32,6 +52,21 @@ func (app *App) SetConfigLoader(loader *config.Loader) {
 	app.ConfigLoader = loader
 }
 
+// SetLogger sets the logger of the application. This is typically called
+// by the logger service provider once the logger has been configured.
+func (app *App) SetLogger(logger *zerolog.Logger) {
+	app.Logger = logger
+}
+
+// GetLogger returns the configured logger of the application, or the
+// global zerolog logger when none has been set.
+func (app *App) GetLogger() *zerolog.Logger {
+	if app.Logger == nil {
+		return &log.Logger
+	}
+	return app.Logger
+}
+
 // SetValidator sets the validator instance for request validation.
 // The validator is used to validate incoming HTTP requests and ensure
 // data integrity before processing.
@@ -46,6 +81,13 @@ func (app *App) GetValidator() *validator.Validate {
 	return app.Validator
 }
 
+// SetDb sets the GORM database connection instance for the application.
+// This is typically called by the database service provider once the
+// connection has been opened.
+func (app *App) SetDb(db *gorm.DB) {
+	app.DB = db
+}
+
 // GetDb returns the GORM database connection instance.
 // This provides access to the database for performing CRUD operations
 // and executing database queries throughout the application.
@@ -53,6 +95,65 @@ func (app *App) GetDb() *gorm.DB {
 	return app.DB
 }
 
+// SetDbConnection stores a named database connection. Setting the
+// DefaultConnection name replaces the connection returned by GetDb.
+func (app *App) SetDbConnection(name string, db *gorm.DB) {
+	if name == DefaultConnection {
+		app.DB = db
+		return
+	}
+	if app.Connections == nil {
+		app.Connections = make(map[string]*gorm.DB)
+	}
+	app.Connections[name] = db
+}
+
+// GetDbConnection returns the database connection registered under the
+// given name, such as one configured through DB_CONNECTIONS. The
+// DefaultConnection name returns the same connection as GetDb. An error
+// wrapping ErrConnectionNotFound is returned for unknown names.
+func (app *App) GetDbConnection(name string) (*gorm.DB, error) {
+	if name == DefaultConnection {
+		if app.DB == nil {
+			return nil, fmt.Errorf("%w: %q", ErrConnectionNotFound, name)
+		}
+		return app.DB, nil
+	}
+
+	db, ok := app.Connections[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrConnectionNotFound, name)
+	}
+	return db, nil
+}
+
+// GetDbConnections returns every database connection keyed by name,
+// including the default connection when one has been set up.
+func (app *App) GetDbConnections() map[string]*gorm.DB {
+	connections := make(map[string]*gorm.DB, len(app.Connections)+1)
+	for name, db := range app.Connections {
+		connections[name] = db
+	}
+	if app.DB != nil {
+		connections[DefaultConnection] = app.DB
+	}
+	return connections
+}
+
+// GetDbStats returns the connection pool statistics of the database
+// connection, such as open, in-use and idle connections. It returns zero
+// stats when no database connection has been set up.
+func (app *App) GetDbStats() sql.DBStats {
+	if app.DB == nil {
+		return sql.DBStats{}
+	}
+	sqlDB, err := app.DB.DB()
+	if err != nil {
+		return sql.DBStats{}
+	}
+	return sqlDB.Stats()
+}
+
 // GetGin returns the Gin HTTP router engine instance.
 // This allows you to register routes, middleware, and handlers
 // for your HTTP endpoints.
@@ -67,42 +168,141 @@ func (app *App) SetGin(engine *gin.Engine) {
 	app.Gin = engine
 }
 
+// GetContainer returns the service container holding the application's
+// type bindings. The container is created on first access so that an App
+// built as a zero value can still register and resolve services.
+func (app *App) GetContainer() *container.Container {
+	if app.Container == nil {
+		app.Container = container.New()
+	}
+	return app.Container
+}
+
+// GetMetricsRegistry returns the Prometheus registry served on /metrics by
+// the http command. It is created on first use with the runtime, database
+// query and connection pool collectors, and custom collectors can be
+// registered on it.
+func (app *App) GetMetricsRegistry() *prometheus.Registry {
+	if app.Metrics == nil {
+		app.Metrics = metrics.NewRegistry()
+		app.Metrics.MustRegister(metrics.NewDBStatsCollector(app.GetDbConnections))
+	}
+	return app.Metrics
+}
+
 // SetupBaseDependencies initializes the core application dependencies.
-// This includes setting up the logger, database connection, and other
-// essential services required for the application to function.
-func (app *App) SetupBaseDependencies() {
-	//app.setupConfig()
-	app.setupLogger()
-	//app.setupDatabaseConnection()
-	app.setupModel()
+// It runs the Register and Boot phases of every registered service provider
+// in dependency order. The built-in providers set up the logger, database
+// connection and validator. A failing provider is reported as a
+// *ProviderError wrapping the cause, such as ErrConfigMissing or
+// ErrDatabaseUnreachable.
+func (app *App) SetupBaseDependencies() error {
+	return app.bootProviders()
+}
+
+// AddMigrations registers versioned database migrations on the application.
+// They are applied by the built-in migrate command in version order.
+func (app *App) AddMigrations(migrations ...migration.Migration) {
+	app.Migrations = append(app.Migrations, migrations...)
+}
+
+// GetMigrations returns the migrations registered on the application.
+func (app *App) GetMigrations() []migration.Migration {
+	return app.Migrations
+}
+
+// AddSeeders registers database seeders on the application. A seeder
+// registered with the same name as an existing one replaces it in place.
+// They are run by the db:seed command in dependency order.
+func (app *App) AddSeeders(seeders ...contracts.SeederContract) {
+	for _, seeder := range seeders {
+		replaced := false
+		for i, registered := range app.Seeders {
+			if registered.Name() == seeder.Name() {
+				app.Seeders[i] = seeder
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			app.Seeders = append(app.Seeders, seeder)
+		}
+	}
+}
+
+// GetSeeders returns the seeders registered on the application in
+// registration order.
+func (app *App) GetSeeders() []contracts.SeederContract {
+	return app.Seeders
+}
+
+// AddHealthChecks registers health checks on the application. A check
+// registered with the same name as an existing one replaces it in place.
+// They are run by the health endpoints of the http command.
+func (app *App) AddHealthChecks(checks ...contracts.HealthCheckContract) {
+	for _, check := range checks {
+		replaced := false
+		for i, registered := range app.HealthChecks {
+			if registered.Name() == check.Name() {
+				app.HealthChecks[i] = check
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			app.HealthChecks = append(app.HealthChecks, check)
+		}
+	}
+}
+
+// GetHealthChecks returns the health checks registered on the application
+// in registration order.
+func (app *App) GetHealthChecks() []contracts.HealthCheckContract {
+	return app.HealthChecks
 }
 
 // AddCommand registers a new CLI command to the application.
 // The provided function should return a CommandContract implementation that
-// defines the command's behavior, usage, and execution logic.
+// defines the command's behavior, usage, and execution logic. The command is
+// built with cli.NewCommand: commands implementing CommandFlagsContract get
+// to define their flags, commands implementing CommandRunEContract return
+// their error to Run, and every run starts a root span, which is available
+// through cmd.Context().
 func (app *App) AddCommand(f func(app contracts.AppContract) clicontracts.CommandContract) {
-	rootCmd := app.Command
-
-	command := f(app)
-
-	rootCmd.AddCommand(&cobra.Command{
-		Use:     command.Use(),
-		Short:   command.Short(),
-		Long:    command.Long(),
-		Example: command.Example(),
-		Run: func(cobra *cobra.Command, args []string) {
-			command.Run(cobra, args)
-		},
-	})
+	if app.Command == nil {
+		app.Command = &cobra.Command{}
+	}
+	app.Command.AddCommand(cli.NewCommand(f(app)))
 }
 
 // Run starts the CLI application and executes the registered commands.
 // This method initializes the CLI interface and begins processing
-// user commands from the command line.
+// user commands from the command line. Once the command returns, every
+// service provider is shut down in reverse order before the process exits.
 func (app *App) Run() {
+	if err := app.execute(); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// execute runs the CLI with the built-in commands and the ones added with
+// AddCommand, which are moved from app.Command onto the CLI root, and shuts
+// down the service providers once the command returns.
+func (app *App) execute() error {
 	cliApp := cli.NewCli(app)
 	//routers.NewCliRouter(cliApp)
-	cliApp.Run()
+	if app.Command != nil {
+		cliApp.Command.AddCommand(app.Command.Commands()...)
+	}
+	// Run prints the error itself once the providers are shut down.
+	cliApp.Command.SilenceErrors = true
+	err := cliApp.Execute()
+
+	if shutdownErr := app.Shutdown(); shutdownErr != nil {
+		log.Error().Err(shutdownErr).Msg("failed to shut down service providers")
+	}
+	return err
 }
 
 // GetConfigLoader returns the configuration loader instance.
@@ -112,42 +312,19 @@ func (app *App) GetConfigLoader() *config.Loader {
 	return app.ConfigLoader
 }
 
-//func (app *App) setupConfig() {
-//	cfg, err := config.NewLoader()
-//	if err != nil {
-//		panic(err)
-//	}
-//	err = cfg.BindTo(&config.Config{}) // todo: move this outside
-//	if err != nil {
-//		panic(err)
-//	}
-//	app.ConfigLoader = cfg
-//}
-
-func (app *App) setupDatabaseConnection() {
-
-}
-
-func (app *App) setupLogger() {
-	NewLogger()
-}
-
-func (app *App) setupModel() {
-	cfg := app.ConfigLoader.Config
-	dbCfg := cfg.GetDb()
-	host := dbCfg.GetHost()
-	port := dbCfg.GetPort()
-	user := dbCfg.GetUser()
-	password := dbCfg.GetPassword()
-	dbName := dbCfg.GetDatabase()
-
-	db := NewGormConnection(host, port, user, password, dbName)
-	app.DB = db
-}
-
 // NewApp creates and returns a new application instance.
 // This is the entry point for initializing the Ponodo framework.
 // The returned instance implements the AppContract interface.
+//
+// The logger, tracing, database and validator service providers are registered by
+// default; use RegisterProvider or RemoveProvider to customize them.
 func NewApp() contracts.AppContract {
-	return &App{}
+	app := &App{
+		Container: container.New(),
+	}
+	app.RegisterProvider(NewLoggerServiceProvider())
+	app.RegisterProvider(NewTracingServiceProvider())
+	app.RegisterProvider(NewDatabaseServiceProvider())
+	app.RegisterProvider(NewValidatorServiceProvider())
+	return app
 }
@@ -1,6 +1,8 @@
 package ponodo
 
 import (
+	"errors"
+	"os"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -8,6 +10,11 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
 
@@ -18,6 +25,7 @@ import (
 	configmocks "github.com/zerpto/ponodo/config/contracts/mocks"
 	"github.com/zerpto/ponodo/contracts"
 	"github.com/zerpto/ponodo/contracts/mocks"
+	"github.com/zerpto/ponodo/migration"
 )
 
 func TestNewApp(t *testing.T) {
@@ -79,6 +87,19 @@ func TestApp_SetupBaseDependencies(t *testing.T) {
 	t.Skip("Skipping database-related test - requires database connection")
 }
 
+func TestApp_SetupBaseDependencies_ConfigMissing(t *testing.T) {
+	app := NewApp()
+
+	err := app.SetupBaseDependencies()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrConfigMissing)
+
+	var providerErr *ProviderError
+	require.ErrorAs(t, err, &providerErr)
+	assert.Equal(t, "database", providerErr.Provider)
+	assert.Equal(t, ProviderPhaseRegister, providerErr.Phase)
+}
+
 func TestApp_AddCommand(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -105,6 +126,201 @@ func TestApp_AddCommand(t *testing.T) {
 	require.NotNil(t, app.Command)
 }
 
+func TestApp_AddCommand_RootSpan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	var commandSpan trace.SpanContext
+	mockCommand := climocks.NewMockCommandContract(ctrl)
+	mockCommand.EXPECT().Use().Return("report").AnyTimes()
+	mockCommand.EXPECT().Short().Return("")
+	mockCommand.EXPECT().Long().Return("")
+	mockCommand.EXPECT().Example().Return("")
+	mockCommand.EXPECT().Run(gomock.Any(), gomock.Any()).Do(func(cmd *cobra.Command, args []string) {
+		commandSpan = trace.SpanContextFromContext(cmd.Context())
+	})
+
+	app := &App{Command: &cobra.Command{Use: "testapp"}}
+	app.AddCommand(func(app contracts.AppContract) clicontracts.CommandContract {
+		return mockCommand
+	})
+	app.Command.SetArgs([]string{"report"})
+	require.NoError(t, app.Command.Execute())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "command report", spans[0].Name())
+	assert.False(t, spans[0].Parent().IsValid())
+	assert.Equal(t, spans[0].SpanContext(), commandSpan)
+}
+
+// mockRunECommand implements the command contract together with the
+// optional RunE contract.
+type mockRunECommand struct {
+	*climocks.MockCommandContract
+	*climocks.MockCommandRunEContract
+}
+
+func TestApp_AddCommand_RunE(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	failure := errors.New("seed users: constraint failed")
+	command := mockRunECommand{climocks.NewMockCommandContract(ctrl), climocks.NewMockCommandRunEContract(ctrl)}
+	command.MockCommandContract.EXPECT().Use().Return("db:seed").AnyTimes()
+	command.MockCommandContract.EXPECT().Short().Return("")
+	command.MockCommandContract.EXPECT().Long().Return("")
+	command.MockCommandContract.EXPECT().Example().Return("")
+	command.MockCommandRunEContract.EXPECT().RunE(gomock.Any(), gomock.Any()).Return(failure)
+
+	app := &App{Command: &cobra.Command{Use: "testapp", SilenceErrors: true}}
+	app.AddCommand(func(app contracts.AppContract) clicontracts.CommandContract {
+		return command
+	})
+	app.Command.SetArgs([]string{"db:seed"})
+	assert.ErrorIs(t, app.Command.Execute(), failure)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
+func TestApp_AddMigrations(t *testing.T) {
+	app := &App{}
+	app.AddMigrations(
+		migration.Migration{Version: "1", Name: "create_users"},
+		migration.Migration{Version: "2", Name: "create_posts"},
+	)
+
+	migrations := app.GetMigrations()
+	require.Len(t, migrations, 2)
+	assert.Equal(t, "create_posts", migrations[1].Name)
+}
+
+func TestApp_AddSeeders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	users := mocks.NewMockSeederContract(ctrl)
+	users.EXPECT().Name().Return("users").AnyTimes()
+	posts := mocks.NewMockSeederContract(ctrl)
+	posts.EXPECT().Name().Return("posts").AnyTimes()
+	replacement := mocks.NewMockSeederContract(ctrl)
+	replacement.EXPECT().Name().Return("users").AnyTimes()
+
+	app := &App{}
+	app.AddSeeders(users, posts)
+	app.AddSeeders(replacement)
+
+	seeders := app.GetSeeders()
+	require.Len(t, seeders, 2)
+	assert.Same(t, replacement, seeders[0])
+	assert.Same(t, posts, seeders[1])
+}
+
+func TestApp_AddHealthChecks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cache := mocks.NewMockHealthCheckContract(ctrl)
+	cache.EXPECT().Name().Return("cache").AnyTimes()
+	queue := mocks.NewMockHealthCheckContract(ctrl)
+	queue.EXPECT().Name().Return("queue").AnyTimes()
+	replacement := mocks.NewMockHealthCheckContract(ctrl)
+	replacement.EXPECT().Name().Return("cache").AnyTimes()
+
+	app := &App{}
+	app.AddHealthChecks(cache, queue)
+	app.AddHealthChecks(replacement)
+
+	checks := app.GetHealthChecks()
+	require.Len(t, checks, 2)
+	assert.Same(t, replacement, checks[0])
+	assert.Same(t, queue, checks[1])
+}
+
+func TestApp_GetMetricsRegistry(t *testing.T) {
+	app := &App{}
+	app.SetDb(&gorm.DB{Config: &gorm.Config{}})
+
+	registry := app.GetMetricsRegistry()
+	require.NotNil(t, registry)
+	assert.Same(t, registry, app.GetMetricsRegistry())
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	names := make([]string, 0, len(families))
+	for _, family := range families {
+		names = append(names, family.GetName())
+	}
+	assert.Contains(t, names, "go_goroutines")
+}
+
+func TestApp_AddCommand_WithoutRootCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCommand := climocks.NewMockCommandContract(ctrl)
+	mockCommand.EXPECT().Use().Return("test").Times(1)
+	mockCommand.EXPECT().Short().Return("test short").Times(1)
+	mockCommand.EXPECT().Long().Return("test long").Times(1)
+	mockCommand.EXPECT().Example().Return("test example").Times(1)
+
+	app := &App{}
+	assert.NotPanics(t, func() {
+		app.AddCommand(func(app contracts.AppContract) clicontracts.CommandContract {
+			return mockCommand
+		})
+	})
+	require.NotNil(t, app.Command)
+	assert.Len(t, app.Command.Commands(), 1)
+}
+
+func TestApp_Run_ExecutesAddedCommands(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConfig := configmocks.NewMockConfigContract(ctrl)
+	mockConfig.EXPECT().GetApp().Return("testapp").AnyTimes()
+
+	var events []string
+	app := &App{ConfigLoader: &config.Loader{Config: mockConfig}}
+	app.started = []contracts.ServiceProviderContract{&recordingProvider{name: "cache", events: &events}}
+
+	failure := errors.New("report failed")
+	command := mockRunECommand{climocks.NewMockCommandContract(ctrl), climocks.NewMockCommandRunEContract(ctrl)}
+	command.MockCommandContract.EXPECT().Use().Return("report").AnyTimes()
+	command.MockCommandContract.EXPECT().Short().Return("")
+	command.MockCommandContract.EXPECT().Long().Return("")
+	command.MockCommandContract.EXPECT().Example().Return("")
+	command.MockCommandRunEContract.EXPECT().RunE(gomock.Any(), []string{"2026"}).DoAndReturn(func(*cobra.Command, []string) error {
+		events = append(events, "run:report")
+		return failure
+	})
+	app.AddCommand(func(app contracts.AppContract) clicontracts.CommandContract {
+		return command
+	})
+
+	args := os.Args
+	os.Args = []string{"testapp", "report", "2026"}
+	defer func() { os.Args = args }()
+
+	assert.ErrorIs(t, app.execute(), failure)
+	assert.Equal(t, []string{"run:report", "shutdown:cache"}, events)
+}
+
 func TestApp_Run(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
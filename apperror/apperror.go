@@ -0,0 +1,121 @@
+package apperror
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+// Error is an application error that knows how it is presented to clients.
+// Code is a stable machine readable identifier, Status the HTTP status,
+// Message a user-safe description and Details optional structured data.
+// Cause is the internal error behind it, which is only exposed to clients
+// outside of release mode.
+type Error struct {
+	Code    string
+	Status  int
+	Message string
+	Details any
+	Cause   error
+}
+
+// Predefined errors for the common HTTP failures. Use WithCause, WithMessage
+// and WithDetails to derive an error carrying more information; the
+// derived error still matches the predefined one with errors.Is.
+var (
+	ErrBadRequest         = New(http.StatusBadRequest, "bad_request", "The request is invalid.")
+	ErrUnauthorized       = New(http.StatusUnauthorized, "unauthorized", "Authentication is required.")
+	ErrForbidden          = New(http.StatusForbidden, "forbidden", "You are not allowed to perform this action.")
+	ErrNotFound           = New(http.StatusNotFound, "not_found", "The requested resource was not found.")
+	ErrConflict           = New(http.StatusConflict, "conflict", "The request conflicts with the current state of the resource.")
+	ErrValidation         = New(http.StatusUnprocessableEntity, "validation_failed", "The request contains invalid fields.")
+	ErrTooManyRequests    = New(http.StatusTooManyRequests, "too_many_requests", "Too many requests, please try again later.")
+	ErrInternal           = New(http.StatusInternalServerError, "internal_error", "An unexpected error occurred.")
+	ErrServiceUnavailable = New(http.StatusServiceUnavailable, "service_unavailable", "The service is temporarily unavailable.")
+)
+
+// New creates an application error with the given status, code and
+// user-safe message.
+func New(status int, code, message string) *Error {
+	return &Error{
+		Code:    code,
+		Status:  status,
+		Message: message,
+	}
+}
+
+// Error returns the message followed by the cause, for logging.
+func (e *Error) Error() string {
+	if e.Cause == nil {
+		return e.Message
+	}
+	return e.Message + ": " + e.Cause.Error()
+}
+
+// Unwrap returns the internal cause of the error.
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether the target is an application error with the same
+// code, so errors derived with the With methods match their origin.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code != "" && t.Code == e.Code
+}
+
+// WithCause returns a copy of the error wrapping the internal cause.
+func (e *Error) WithCause(cause error) *Error {
+	clone := *e
+	clone.Cause = cause
+	return &clone
+}
+
+// WithMessage returns a copy of the error with another user-safe message.
+func (e *Error) WithMessage(message string) *Error {
+	clone := *e
+	clone.Message = message
+	return &clone
+}
+
+// WithDetails returns a copy of the error carrying structured details,
+// such as the fields of a conflicting resource.
+func (e *Error) WithDetails(details any) *Error {
+	clone := *e
+	clone.Details = details
+	return &clone
+}
+
+// Wrap annotates an internal error with the status, code and message of
+// the template, for example apperror.Wrap(err, apperror.ErrConflict).
+func Wrap(cause error, template *Error) *Error {
+	return template.WithCause(cause)
+}
+
+// From converts any error into an application error. Application errors
+// are returned as is, validation errors map to ErrValidation, record not
+// found errors from GORM map to ErrNotFound and every other error maps to
+// ErrInternal. The original error is kept as the cause.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	var validationErrors validator.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrors):
+		return ErrValidation.WithCause(err)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrNotFound.WithCause(err)
+	default:
+		return ErrInternal.WithCause(err)
+	}
+}
+
+// StatusOf returns the HTTP status for the error as mapped by From.
+func StatusOf(err error) int {
+	return From(err).Status
+}
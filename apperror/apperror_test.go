@@ -0,0 +1,52 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestError_WithCause(t *testing.T) {
+	cause := errors.New("duplicate key value violates unique constraint")
+	err := Wrap(cause, ErrConflict).WithMessage("The email is already taken.")
+
+	assert.Equal(t, "The email is already taken.: duplicate key value violates unique constraint", err.Error())
+	assert.True(t, errors.Is(err, ErrConflict))
+	assert.True(t, errors.Is(err, cause))
+	assert.False(t, errors.Is(err, ErrNotFound))
+	assert.Nil(t, ErrConflict.Cause, "predefined errors must not be modified")
+}
+
+func TestFrom(t *testing.T) {
+	type payload struct {
+		Name string `validate:"required"`
+	}
+	validationErr := validator.New().Struct(payload{})
+	custom := New(http.StatusPaymentRequired, "payment_required", "Upgrade your plan.")
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{name: "application error", err: fmt.Errorf("charge: %w", custom), status: http.StatusPaymentRequired, code: "payment_required"},
+		{name: "validation error", err: validationErr, status: http.StatusUnprocessableEntity, code: "validation_failed"},
+		{name: "record not found", err: fmt.Errorf("find user: %w", gorm.ErrRecordNotFound), status: http.StatusNotFound, code: "not_found"},
+		{name: "other error", err: errors.New("connection refused"), status: http.StatusInternalServerError, code: "internal_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := From(tt.err)
+			assert.Equal(t, tt.status, err.Status)
+			assert.Equal(t, tt.code, err.Code)
+			assert.Equal(t, tt.status, StatusOf(tt.err))
+		})
+	}
+}
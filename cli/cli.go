@@ -5,6 +5,7 @@ import (
 	"os"
 
 	clicontracts "github.com/zerpto/ponodo/cli/contracts"
+	"github.com/zerpto/ponodo/cli/handlers"
 	"github.com/zerpto/ponodo/contracts"
 
 	"github.com/spf13/cobra"
@@ -32,6 +33,12 @@ func (cli *Cli) Run() {
 	}
 }
 
+// Execute runs the root command and returns its error instead of exiting.
+// This lets the caller release resources before deciding on an exit code.
+func (cli *Cli) Execute() error {
+	return cli.Command.Execute()
+}
+
 // SetRootCommand sets the root Cobra command for the CLI application.
 // This command serves as the entry point for all registered subcommands
 // and defines the base command structure.
@@ -41,26 +48,16 @@ func (cli *Cli) SetRootCommand(cmd *cobra.Command) {
 
 // AddCommand registers a new subcommand to the CLI application.
 // The provided function should return a CommandContract implementation
-// that defines the command's behavior, usage, and execution logic.
+// that defines the command's behavior, usage, and execution logic. The
+// command is built with NewCommand, so every run is traced.
 func (cli *Cli) AddCommand(f func(app contracts.AppContract) clicontracts.CommandContract) {
-	rootCmd := cli.Command
-
-	command := f(cli.App)
-
-	rootCmd.AddCommand(&cobra.Command{
-		Use:     command.Use(),
-		Short:   command.Short(),
-		Long:    command.Long(),
-		Example: command.Example(),
-		Run: func(cobra *cobra.Command, args []string) {
-			command.Run(cobra, args)
-		},
-	})
+	cli.Command.AddCommand(NewCommand(f(cli.App)))
 }
 
 // NewCli creates and initializes a new CLI application instance.
-// It sets up the root command based on the application configuration
-// and returns a ready-to-use CLI instance.
+// It sets up the root command based on the application configuration,
+// adds the built-in migrate command, and returns a ready-to-use CLI instance.
+// Runs of the migrate subcommands are traced like every added command.
 func NewCli(app contracts.AppContract) *Cli {
 	cli := &Cli{
 		App: app,
@@ -71,6 +68,7 @@ func NewCli(app contracts.AppContract) *Cli {
 		Use:   config.GetApp(),
 		Short: fmt.Sprintf("%s Service", config.GetApp()),
 	}
+	rootCmd.AddCommand(Trace(handlers.NewMigrateCommand(app)))
 	cli.SetRootCommand(rootCmd)
 	return cli
 }
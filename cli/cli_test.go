@@ -33,6 +33,10 @@ func TestNewCli(t *testing.T) {
 	require.NotNil(t, cli)
 	assert.Equal(t, mockApp, cli.App)
 	assert.NotNil(t, cli.Command)
+
+	migrateCmd, _, err := cli.Command.Find([]string{"migrate", "up"})
+	require.NoError(t, err)
+	assert.Equal(t, "up", migrateCmd.Name())
 }
 
 func TestCli_SetRootCommand(t *testing.T) {
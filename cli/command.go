@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"strings"
+
+	"github.com/spf13/cobra"
+	clicontracts "github.com/zerpto/ponodo/cli/contracts"
+	"github.com/zerpto/ponodo/tracing"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewCommand builds the Cobra command of a CommandContract implementation.
+// Commands implementing CommandFlagsContract get to define their flags, and
+// commands implementing CommandRunEContract return their error from
+// Execute. Every run is traced, see Trace.
+func NewCommand(command clicontracts.CommandContract) *cobra.Command {
+	withError, returnsError := command.(clicontracts.CommandRunEContract)
+
+	cobraCmd := &cobra.Command{
+		Use:          command.Use(),
+		Short:        command.Short(),
+		Long:         command.Long(),
+		Example:      command.Example(),
+		SilenceUsage: returnsError,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !returnsError {
+				command.Run(cmd, args)
+				return nil
+			}
+			return withError.RunE(cmd, args)
+		},
+	}
+	if withFlags, ok := command.(clicontracts.CommandFlagsContract); ok {
+		withFlags.Flags(cobraCmd.Flags())
+	}
+	return Trace(cobraCmd)
+}
+
+// Trace wraps the command and its subcommands so that every run starts a
+// root span named after the command path below the root, such as
+// "command migrate up". The span is available through cmd.Context() and
+// records the error returned by the command. It returns cmd.
+func Trace(cmd *cobra.Command) *cobra.Command {
+	for _, sub := range cmd.Commands() {
+		Trace(sub)
+	}
+
+	switch {
+	case cmd.RunE != nil:
+		run := cmd.RunE
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			return runTraced(cmd, func() error {
+				return run(cmd, args)
+			})
+		}
+	case cmd.Run != nil:
+		run := cmd.Run
+		cmd.Run = nil
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			return runTraced(cmd, func() error {
+				run(cmd, args)
+				return nil
+			})
+		}
+	}
+	return cmd
+}
+
+func runTraced(cmd *cobra.Command, run func() error) error {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	name := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	ctx, span := tracing.Tracer().Start(ctx, "command "+name, trace.WithNewRoot())
+	defer span.End()
+
+	cmd.SetContext(ctx)
+	if err := run(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	clicontracts "github.com/zerpto/ponodo/cli/contracts"
+	climocks "github.com/zerpto/ponodo/cli/contracts/mocks"
+	"github.com/zerpto/ponodo/config"
+	configmocks "github.com/zerpto/ponodo/config/contracts/mocks"
+	"github.com/zerpto/ponodo/contracts"
+	"github.com/zerpto/ponodo/contracts/mocks"
+)
+
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestCli_AddCommand_RootSpan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	recorder := recordSpans(t)
+
+	var commandSpan trace.SpanContext
+	mockCommand := climocks.NewMockCommandContract(ctrl)
+	mockCommand.EXPECT().Use().Return("report").AnyTimes()
+	mockCommand.EXPECT().Short().Return("")
+	mockCommand.EXPECT().Long().Return("")
+	mockCommand.EXPECT().Example().Return("")
+	mockCommand.EXPECT().Run(gomock.Any(), gomock.Any()).Do(func(cmd *cobra.Command, args []string) {
+		commandSpan = trace.SpanContextFromContext(cmd.Context())
+	})
+
+	cli := &Cli{App: mocks.NewMockAppContract(ctrl), Command: &cobra.Command{Use: "testapp"}}
+	cli.AddCommand(func(app contracts.AppContract) clicontracts.CommandContract {
+		return mockCommand
+	})
+	cli.Command.SetArgs([]string{"report"})
+	require.NoError(t, cli.Execute())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "command report", spans[0].Name())
+	assert.False(t, spans[0].Parent().IsValid())
+	assert.Equal(t, spans[0].SpanContext(), commandSpan)
+}
+
+func TestNewCli_TracesMigrateCommands(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	recorder := recordSpans(t)
+
+	mockApp := mocks.NewMockAppContract(ctrl)
+	mockConfig := configmocks.NewMockConfigContract(ctrl)
+	mockConfig.EXPECT().GetApp().Return("testapp").AnyTimes()
+	mockApp.EXPECT().GetConfigLoader().Return(&config.Loader{Config: mockConfig}).AnyTimes()
+	mockApp.EXPECT().GetDb().Return(nil).AnyTimes()
+
+	cli := NewCli(mockApp)
+	cli.Command.SilenceErrors = true
+	cli.Command.SetArgs([]string{"migrate", "status"})
+	require.Error(t, cli.Execute())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "command migrate status", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
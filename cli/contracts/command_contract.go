@@ -1,6 +1,9 @@
 package contracts
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
 
 // CommandContract defines the interface for CLI command implementations.
 // Commands implementing this interface provide usage information, descriptions,
@@ -14,3 +17,18 @@ type CommandContract interface {
 	Example() string
 	Run(cmd *cobra.Command, args []string)
 }
+
+// CommandFlagsContract is an optional interface for commands that accept
+// flags. When a registered command implements it, Flags is called with the
+// flag set of the Cobra command before the command is executed.
+type CommandFlagsContract interface {
+	Flags(flags *pflag.FlagSet)
+}
+
+// CommandRunEContract is an optional interface for commands that can fail.
+// When a registered command implements it, RunE is run instead of Run and
+// its error is returned from the command, so the application shuts down
+// its service providers before exiting with a non-zero code.
+type CommandRunEContract interface {
+	RunE(cmd *cobra.Command, args []string) error
+}
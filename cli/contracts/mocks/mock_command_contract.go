@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 
 	cobra "github.com/spf13/cobra"
+	pflag "github.com/spf13/pflag"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -107,3 +108,77 @@ func (mr *MockCommandContractMockRecorder) Use() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Use", reflect.TypeOf((*MockCommandContract)(nil).Use))
 }
+
+// MockCommandFlagsContract is a mock of CommandFlagsContract interface.
+type MockCommandFlagsContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommandFlagsContractMockRecorder
+	isgomock struct{}
+}
+
+// MockCommandFlagsContractMockRecorder is the mock recorder for MockCommandFlagsContract.
+type MockCommandFlagsContractMockRecorder struct {
+	mock *MockCommandFlagsContract
+}
+
+// NewMockCommandFlagsContract creates a new mock instance.
+func NewMockCommandFlagsContract(ctrl *gomock.Controller) *MockCommandFlagsContract {
+	mock := &MockCommandFlagsContract{ctrl: ctrl}
+	mock.recorder = &MockCommandFlagsContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommandFlagsContract) EXPECT() *MockCommandFlagsContractMockRecorder {
+	return m.recorder
+}
+
+// Flags mocks base method.
+func (m *MockCommandFlagsContract) Flags(flags *pflag.FlagSet) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Flags", flags)
+}
+
+// Flags indicates an expected call of Flags.
+func (mr *MockCommandFlagsContractMockRecorder) Flags(flags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flags", reflect.TypeOf((*MockCommandFlagsContract)(nil).Flags), flags)
+}
+
+// MockCommandRunEContract is a mock of CommandRunEContract interface.
+type MockCommandRunEContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommandRunEContractMockRecorder
+	isgomock struct{}
+}
+
+// MockCommandRunEContractMockRecorder is the mock recorder for MockCommandRunEContract.
+type MockCommandRunEContractMockRecorder struct {
+	mock *MockCommandRunEContract
+}
+
+// NewMockCommandRunEContract creates a new mock instance.
+func NewMockCommandRunEContract(ctrl *gomock.Controller) *MockCommandRunEContract {
+	mock := &MockCommandRunEContract{ctrl: ctrl}
+	mock.recorder = &MockCommandRunEContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommandRunEContract) EXPECT() *MockCommandRunEContractMockRecorder {
+	return m.recorder
+}
+
+// RunE mocks base method.
+func (m *MockCommandRunEContract) RunE(cmd *cobra.Command, args []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunE", cmd, args)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunE indicates an expected call of RunE.
+func (mr *MockCommandRunEContractMockRecorder) RunE(cmd, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunE", reflect.TypeOf((*MockCommandRunEContract)(nil).RunE), cmd, args)
+}
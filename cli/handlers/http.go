@@ -4,19 +4,27 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os/signal"
 	"syscall"
 	"time"
 
 	clicontracts "github.com/zerpto/ponodo/cli/contracts"
+	"github.com/zerpto/ponodo/config"
 	"github.com/zerpto/ponodo/contracts"
+	"github.com/zerpto/ponodo/health"
+	"github.com/zerpto/ponodo/metrics"
+	"github.com/zerpto/ponodo/middleware"
+	"github.com/zerpto/ponodo/timing"
+	"github.com/zerpto/ponodo/tracing"
+	"github.com/zerpto/ponodo/validation"
 
 	"github.com/gin-gonic/gin"
+	"github.com/quic-go/quic-go/http3"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
-
-	"github.com/go-playground/validator/v10"
+	"github.com/spf13/pflag"
 )
 
 // HttpHandler represents a CLI command handler for running an HTTP server.
@@ -48,61 +56,314 @@ func (h *HttpHandler) Example() string {
 	return `zerpto http --port 8080`
 }
 
-// Run executes the HTTP server command. It initializes the Gin router,
-// sets up graceful shutdown handling, and starts the HTTP server on
-// the configured port with proper signal handling.
+// Flags defines the listen address, serving mode, timeout and TLS flags of the HTTP
+// command. Flags that are set take precedence over the HTTP_* config keys.
+func (h *HttpHandler) Flags(flags *pflag.FlagSet) {
+	flags.String("host", "", "host to listen on (HTTP_HOST)")
+	flags.String("port", config.DefaultHttpPort, "port to listen on (HTTP_PORT)")
+	flags.String("mode", config.HttpModeHTTP1, "serving mode: http1, h2c or http3, which requires TLS (HTTP_MODE)")
+	flags.Duration("read-timeout", config.DefaultHttpReadTimeout, "maximum duration for reading the entire request (HTTP_READ_TIMEOUT)")
+	flags.Duration("read-header-timeout", config.DefaultHttpReadHeaderTimeout, "maximum duration for reading the request headers (HTTP_READ_HEADER_TIMEOUT)")
+	flags.Duration("write-timeout", config.DefaultHttpWriteTimeout, "maximum duration before timing out writes of the response (HTTP_WRITE_TIMEOUT)")
+	flags.Duration("idle-timeout", config.DefaultHttpIdleTimeout, "maximum time to wait for the next request on keep-alive connections (HTTP_IDLE_TIMEOUT)")
+	flags.Duration("shutdown-timeout", config.DefaultHttpShutdownTimeout, "grace period for in-flight requests on shutdown (HTTP_SHUTDOWN_TIMEOUT)")
+	flags.Int("max-header-bytes", config.DefaultHttpMaxHeaderBytes, "maximum size of the request headers (HTTP_MAX_HEADER_BYTES)")
+	flags.String("tls-cert", "", "TLS certificate file, reloaded when it changes (HTTP_TLS_CERT)")
+	flags.String("tls-key", "", "TLS private key file, reloaded when it changes (HTTP_TLS_KEY)")
+}
+
+// Run executes the HTTP server command and logs the error when it fails.
+// Commands registered with AddCommand run RunE instead.
 func (h *HttpHandler) Run(cmd *cobra.Command, args []string) {
+	if err := h.RunE(cmd, args); err != nil {
+		log.Error().Err(err).Msg("http server failed")
+	}
+}
+
+// RunE executes the HTTP server command. It initializes the Gin router,
+// sets up graceful shutdown handling, and starts the HTTP server on
+// the configured address with proper signal handling. An error is returned
+// when the server cannot be started or fails while serving.
+func (h *HttpHandler) RunE(cmd *cobra.Command, args []string) error {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	if err := h.serve(ctx, stop, cmd); err != nil {
+		return err
+	}
+	log.Info().Msg("Server exiting")
+	return nil
+}
+
+// serve runs the HTTP server until the context is cancelled and then shuts
+// it down gracefully within the configured grace period. stop is called as
+// soon as the context is done, before the shutdown starts, so that signal
+// handling is restored and a second interrupt terminates the process. In
+// http3 mode an HTTP/3 server is run on the same port over UDP next to the
+// TCP server and both are shut down together.
+func (h *HttpHandler) serve(ctx context.Context, stop context.CancelFunc, cmd *cobra.Command) error {
+	httpConfig, err := h.httpConfig(cmd)
+	if err != nil {
+		return err
+	}
+	if err := validateHttpMode(httpConfig); err != nil {
+		return err
+	}
+
+	checker := health.New(h.healthChecks()...)
+	srv := &http.Server{
+		Addr:              httpConfig.Addr(),
+		Handler:           h.setupRouter(checker),
+		ReadTimeout:       httpConfig.ReadTimeout,
+		ReadHeaderTimeout: httpConfig.ReadHeaderTimeout,
+		WriteTimeout:      httpConfig.WriteTimeout,
+		IdleTimeout:       httpConfig.IdleTimeout,
+		MaxHeaderBytes:    httpConfig.MaxHeaderBytes,
+	}
+
+	if httpConfig.TLSEnabled() {
+		reloader, err := newCertReloader(httpConfig.TLSCert, httpConfig.TLSKey)
+		if err != nil {
+			return err
+		}
+		if err := reloader.Watch(ctx); err != nil {
+			return err
+		}
+		srv.TLSConfig = reloader.TLSConfig()
+	}
+
+	if httpConfig.Mode == config.HttpModeH2C {
+		srv.Protocols = new(http.Protocols)
+		srv.Protocols.SetHTTP1(true)
+		srv.Protocols.SetUnencryptedHTTP2(true)
+		srv.Protocols.SetHTTP2(srv.TLSConfig != nil)
+	}
+
+	listener, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+
+	var (
+		h3Srv  *http3.Server
+		h3Conn net.PacketConn
+	)
+	if httpConfig.Mode == config.HttpModeHTTP3 {
+		// Listen on the port actually bound over TCP, so that an ephemeral
+		// port serves both protocols.
+		h3Conn, err = net.ListenPacket("udp", listener.Addr().String())
+		if err != nil {
+			_ = listener.Close()
+			return fmt.Errorf("listen: %w", err)
+		}
+		defer func() { _ = h3Conn.Close() }()
+
+		h3Srv = &http3.Server{
+			Handler:        srv.Handler,
+			TLSConfig:      http3.ConfigureTLSConfig(srv.TLSConfig.Clone()),
+			MaxHeaderBytes: httpConfig.MaxHeaderBytes,
+			IdleTimeout:    httpConfig.IdleTimeout,
+		}
+		srv.Handler = altSvcHandler(h3Srv, srv.Handler)
+	}
+
+	log.Info().
+		Str("addr", listener.Addr().String()).
+		Str("mode", httpConfig.Mode).
+		Bool("tls", srv.TLSConfig != nil).
+		Msg("http server listening")
+
+	// Serving in a goroutine so that it won't block the graceful
+	// shutdown handling below
+	serveErr := make(chan error, 2)
+	go func() {
+		if srv.TLSConfig != nil {
+			serveErr <- srv.ServeTLS(listener, "", "")
+		} else {
+			serveErr <- srv.Serve(listener)
+		}
+	}()
+	if h3Srv != nil {
+		go func() {
+			serveErr <- h3Srv.Serve(h3Conn)
+		}()
+	}
+
+	// Listen for the interrupt signal, or stop both servers when one of
+	// them fails.
+	var failure error
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			failure = fmt.Errorf("serve: %w", err)
+		}
+		if h3Srv == nil {
+			return failure
+		}
+	case <-ctx.Done():
+		// Restore default behavior on the interrupt signal and notify user
+		// of shutdown.
+		stop()
+		log.Info().Msg("shutting down gracefully, press Ctrl+C again to force")
+	}
+
+	// Fail readiness right away so that no new traffic is routed to the
+	// server while in-flight requests drain.
+	checker.SetShuttingDown()
+
+	// The context is used to inform the server how long it has to finish
+	// the requests it is currently handling
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), httpConfig.ShutdownTimeout)
+	defer cancel()
+
+	errs := []error{failure}
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		errs = append(errs, fmt.Errorf("server forced to shutdown: %w", err))
+	}
+	if h3Srv != nil {
+		if err := h3Srv.Shutdown(shutdownCtx); err != nil {
+			errs = append(errs, fmt.Errorf("http3 server forced to shutdown: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateHttpMode reports an unknown serving mode, and http3 mode without
+// a TLS certificate since QUIC always runs over TLS.
+func validateHttpMode(httpConfig *config.HttpConfig) error {
+	switch httpConfig.Mode {
+	case config.HttpModeHTTP1, config.HttpModeH2C:
+		return nil
+	case config.HttpModeHTTP3:
+		if !httpConfig.TLSEnabled() {
+			return fmt.Errorf("http mode %q requires tls-cert and tls-key", httpConfig.Mode)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown http mode %q, expected %s, %s or %s",
+			httpConfig.Mode, config.HttpModeHTTP1, config.HttpModeH2C, config.HttpModeHTTP3)
+	}
+}
+
+// altSvcHandler advertises the HTTP/3 server through the Alt-Svc header on
+// responses served over TCP, so that clients can upgrade to QUIC.
+func altSvcHandler(h3Srv *http3.Server, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = h3Srv.SetQUICHeaders(w.Header())
+		next.ServeHTTP(w, r)
+	})
+}
+
+// setupRouter creates the Gin engine with the built-in middleware, the
+// health endpoints and the metrics endpoint, stores it on the application
+// and runs the router setup function. Timing comes first to cover the whole
+// request. The health and metrics endpoints are mounted before tracing,
+// access logging and request metrics are added, so that probes and scrapes
+// do not flood the traces, logs and metrics.
+func (h *HttpHandler) setupRouter(checker *health.Health) *gin.Engine {
 	// Set Gin
 	cfg := h.App.GetConfigLoader().Config
-	if cfg.GetDebug() {
+	debug := cfg.GetDebug()
+	if debug {
 		gin.SetMode(gin.DebugMode)
 	} else {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
 	r := gin.New()
+	registry := h.App.GetMetricsRegistry()
+	r.Use(timing.Middleware(), middleware.RequestID())
+
+	// Gin only applies the middleware added with Use to the routes
+	// registered afterwards.
+	probes := r.Group("", middleware.Recovery(debug))
+	checker.Register(probes)
+	probes.GET(metrics.Path, metrics.Handler(registry))
+
+	r.Use(
+		tracing.Middleware(),
+		middleware.AccessLog(h.App.GetLogger()),
+		metrics.Middleware(registry),
+		middleware.Recovery(debug),
+	)
 	h.App.SetGin(r)
 
 	if h.RouterSetupFn != nil {
 		h.RouterSetupFn(h.App)
 	}
 
-	// Set validator
-	v := validator.New(validator.WithRequiredStructEnabled())
-	h.App.SetValidator(v)
-
-	srv := &http.Server{
-		Addr:    ":8080",
-		Handler: r,
+	// Set validator unless a service provider already did
+	if h.App.GetValidator() == nil {
+		v := validation.New()
+		h.App.SetValidator(v)
 	}
+	return r
+}
 
-	// Initializing the server in a goroutine so that
-	// it won't block the graceful shutdown handling below
-	go func() {
-		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			log.Fatal().Msg(fmt.Sprintf("listen: %s\n", err))
+// healthChecks returns the checks registered on the application, preceded
+// by the built-in database check when a database connection is set up and
+// no check of the same name has been registered.
+func (h *HttpHandler) healthChecks() []contracts.HealthCheckContract {
+	registered := h.App.GetHealthChecks()
+	if h.App.GetDb() == nil {
+		return registered
+	}
+	for _, check := range registered {
+		if check.Name() == health.DatabaseCheckName {
+			return registered
 		}
-	}()
+	}
+	return append([]contracts.HealthCheckContract{health.DatabaseCheck(h.App)}, registered...)
+}
 
-	// Listen for the interrupt signal.
-	<-ctx.Done()
+// httpConfig reads the server configuration from the config keys and
+// applies the flags that have been set on the command line.
+func (h *HttpHandler) httpConfig(cmd *cobra.Command) (*config.HttpConfig, error) {
+	httpConfig, err := h.App.GetConfigLoader().GetHttpConfig()
+	if err != nil {
+		return nil, err
+	}
 
-	// Restore default behavior on the interrupt signal and notify user of shutdown.
-	stop()
-	log.Info().Msg("shutting down gracefully, press Ctrl+C again to force")
+	flags := cmd.Flags()
+	var errs []error
+	setString := func(name string, target *string) {
+		if flags.Changed(name) {
+			value, err := flags.GetString(name)
+			errs = append(errs, err)
+			*target = value
+		}
+	}
+	setDuration := func(name string, target *time.Duration) {
+		if flags.Changed(name) {
+			value, err := flags.GetDuration(name)
+			errs = append(errs, err)
+			*target = value
+		}
+	}
 
-	// The context is used to inform the server it has 5 seconds to finish
-	// the request it is currently handling
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatal().Msg(fmt.Sprintf("Server forced to shutdown: %v", err))
+	setString("host", &httpConfig.Host)
+	setString("port", &httpConfig.Port)
+	setString("mode", &httpConfig.Mode)
+	setDuration("read-timeout", &httpConfig.ReadTimeout)
+	setDuration("read-header-timeout", &httpConfig.ReadHeaderTimeout)
+	setDuration("write-timeout", &httpConfig.WriteTimeout)
+	setDuration("idle-timeout", &httpConfig.IdleTimeout)
+	setDuration("shutdown-timeout", &httpConfig.ShutdownTimeout)
+	setString("tls-cert", &httpConfig.TLSCert)
+	setString("tls-key", &httpConfig.TLSKey)
+	if flags.Changed("max-header-bytes") {
+		value, err := flags.GetInt("max-header-bytes")
+		errs = append(errs, err)
+		httpConfig.MaxHeaderBytes = value
 	}
 
-	log.Info().Msg("Server exiting")
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	if err := httpConfig.Validate(); err != nil {
+		return nil, err
+	}
+	return httpConfig, nil
 }
 
 // Use returns the command name used to invoke this handler.
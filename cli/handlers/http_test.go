@@ -1,18 +1,43 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
 
 	"github.com/zerpto/ponodo/config"
 	configmocks "github.com/zerpto/ponodo/config/contracts/mocks"
 	"github.com/zerpto/ponodo/contracts"
 	"github.com/zerpto/ponodo/contracts/mocks"
+	"github.com/zerpto/ponodo/health"
+	"github.com/zerpto/ponodo/metrics"
+	"github.com/zerpto/ponodo/middleware"
+	"github.com/zerpto/ponodo/timing"
 )
 
 func TestHttpHandler_Short(t *testing.T) {
@@ -119,3 +144,404 @@ func TestNewHttpHandler(t *testing.T) {
 	assert.Equal(t, mockApp, handler.App)
 	assert.NotNil(t, handler.RouterSetupFn)
 }
+
+func newServeTestHandler(t *testing.T, ctrl *gomock.Controller) *HttpHandler {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	mockApp := mocks.NewMockAppContract(ctrl)
+	mockConfig := configmocks.NewMockConfigContract(ctrl)
+	mockConfig.EXPECT().GetDebug().Return(false).AnyTimes()
+	mockApp.EXPECT().GetConfigLoader().Return(&config.Loader{Config: mockConfig}).AnyTimes()
+	mockApp.EXPECT().SetGin(gomock.Any()).AnyTimes()
+	mockApp.EXPECT().GetValidator().Return(validator.New()).AnyTimes()
+	mockApp.EXPECT().GetHealthChecks().Return(nil).AnyTimes()
+	mockApp.EXPECT().GetDb().Return(nil).AnyTimes()
+	mockApp.EXPECT().GetMetricsRegistry().Return(prometheus.NewRegistry()).AnyTimes()
+	nop := zerolog.Nop()
+	mockApp.EXPECT().GetLogger().Return(&nop).AnyTimes()
+
+	return &HttpHandler{
+		App: mockApp,
+	}
+}
+
+func newHttpCommand(handler *HttpHandler, args ...string) *cobra.Command {
+	cmd := &cobra.Command{Use: handler.Use()}
+	handler.Flags(cmd.Flags())
+	if err := cmd.ParseFlags(args); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
+func freePort(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = listener.Close() }()
+	return strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+}
+
+func TestHttpHandler_Flags(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("http_port", "9000")
+	viper.Set("http_idle_timeout", "1m")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := newServeTestHandler(t, ctrl)
+	cmd := newHttpCommand(handler, "--host", "127.0.0.1", "--read-timeout", "3s", "--max-header-bytes", "2048")
+
+	httpConfig, err := handler.httpConfig(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:9000", httpConfig.Addr())
+	assert.Equal(t, 3*time.Second, httpConfig.ReadTimeout)
+	assert.Equal(t, time.Minute, httpConfig.IdleTimeout)
+	assert.Equal(t, config.DefaultHttpWriteTimeout, httpConfig.WriteTimeout)
+	assert.Equal(t, 2048, httpConfig.MaxHeaderBytes)
+}
+
+func TestHttpHandler_Serve(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := newServeTestHandler(t, ctrl)
+	port := freePort(t)
+	cmd := newHttpCommand(handler, "--host", "127.0.0.1", "--port", port, "--shutdown-timeout", "1s")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- handler.serve(ctx, cancel, cmd) }()
+
+	require.Eventually(t, func() bool {
+		resp, err := http.Get("http://127.0.0.1:" + port + "/missing")
+		if err != nil {
+			return false
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode == http.StatusNotFound
+	}, 5*time.Second, 20*time.Millisecond)
+
+	resp, err := http.Get("http://127.0.0.1:" + port + "/readyz")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get(middleware.RequestIDHeader))
+
+	resp, err = http.Get("http://127.0.0.1:" + port + "/metrics")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `route="`+metrics.UnmatchedRoute+`",status="404"`)
+	assert.NotContains(t, string(body), `route="/readyz"`)
+
+	// A connection the client dialed but never sent a request on keeps the
+	// server from shutting down for several seconds.
+	http.DefaultClient.CloseIdleConnections()
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+}
+
+func TestHttpHandler_ServeListenError(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = listener.Close() }()
+	port := strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+
+	handler := newServeTestHandler(t, ctrl)
+	err = handler.serve(context.Background(), func() {}, newHttpCommand(handler, "--host", "127.0.0.1", "--port", port))
+	assert.Error(t, err)
+}
+
+// startServe runs the server in the background and returns a function that
+// stops it and reports the error returned by serve.
+func startServe(t *testing.T, handler *HttpHandler, cmd *cobra.Command) func() error {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- handler.serve(ctx, cancel, cmd) }()
+
+	return func() error {
+		cancel()
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(5 * time.Second):
+			t.Fatal("server did not shut down")
+			return nil
+		}
+	}
+}
+
+func TestHttpHandler_ServeH2C(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := newServeTestHandler(t, ctrl)
+	port := freePort(t)
+	stop := startServe(t, handler, newHttpCommand(handler, "--host", "127.0.0.1", "--port", port, "--mode", "h2c"))
+
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	client := &http.Client{Transport: &http.Transport{Protocols: protocols}}
+	defer client.CloseIdleConnections()
+
+	require.Eventually(t, func() bool {
+		resp, err := client.Get("http://127.0.0.1:" + port + "/missing")
+		if err != nil {
+			return false
+		}
+		_ = resp.Body.Close()
+		return resp.ProtoMajor == 2 && resp.StatusCode == http.StatusNotFound
+	}, 5*time.Second, 20*time.Millisecond)
+
+	assert.NoError(t, stop())
+}
+
+func TestHttpHandler_ServeHTTP3(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	certFile, keyFile := writeTestCertificate(t, t.TempDir(), "localhost")
+	handler := newServeTestHandler(t, ctrl)
+	port := freePort(t)
+	stop := startServe(t, handler, newHttpCommand(handler,
+		"--host", "127.0.0.1", "--port", port, "--mode", "http3",
+		"--tls-cert", certFile, "--tls-key", keyFile, "--shutdown-timeout", "1s"))
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: true}
+
+	// The TCP server advertises HTTP/3 through Alt-Svc.
+	tcpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	defer tcpClient.CloseIdleConnections()
+	require.Eventually(t, func() bool {
+		resp, err := tcpClient.Get("https://127.0.0.1:" + port + "/missing")
+		if err != nil {
+			return false
+		}
+		_ = resp.Body.Close()
+		return resp.Header.Get("Alt-Svc") == `h3=":`+port+`"; ma=2592000`
+	}, 5*time.Second, 20*time.Millisecond)
+
+	transport := &http3.Transport{TLSClientConfig: tlsConfig}
+	defer func() { _ = transport.Close() }()
+	resp, err := (&http.Client{Transport: transport}).Get("https://127.0.0.1:" + port + "/missing")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, 3, resp.ProtoMajor)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	assert.NoError(t, stop())
+}
+
+func TestHttpHandler_ServeInvalidMode(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := newServeTestHandler(t, ctrl)
+
+	err := handler.serve(context.Background(), func() {}, newHttpCommand(handler, "--mode", "spdy"))
+	assert.ErrorContains(t, err, `unknown http mode "spdy"`)
+
+	err = handler.serve(context.Background(), func() {}, newHttpCommand(handler, "--mode", "http3"))
+	assert.ErrorContains(t, err, "requires tls-cert and tls-key")
+}
+
+func TestHttpHandler_ServePartialTLSFlags(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := newServeTestHandler(t, ctrl)
+
+	err := handler.serve(context.Background(), func() {}, newHttpCommand(handler, "--tls-cert", "/etc/tls/server.pem"))
+	assert.ErrorIs(t, err, config.ErrConfigInvalid)
+}
+
+func TestHttpHandler_HealthChecks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "health.db")), &gorm.Config{})
+	require.NoError(t, err)
+
+	cache := mocks.NewMockHealthCheckContract(ctrl)
+	cache.EXPECT().Name().Return("cache").AnyTimes()
+	database := mocks.NewMockHealthCheckContract(ctrl)
+	database.EXPECT().Name().Return(health.DatabaseCheckName).AnyTimes()
+
+	mockApp := mocks.NewMockAppContract(ctrl)
+	mockApp.EXPECT().GetDb().Return(db).AnyTimes()
+	handler := &HttpHandler{App: mockApp}
+
+	// The built-in database check comes first when a connection is set up.
+	mockApp.EXPECT().GetHealthChecks().Return([]contracts.HealthCheckContract{cache})
+	checks := handler.healthChecks()
+	require.Len(t, checks, 2)
+	assert.Equal(t, health.DatabaseCheckName, checks[0].Name())
+	assert.Same(t, cache, checks[1])
+
+	// A registered database check replaces the built-in one.
+	mockApp.EXPECT().GetHealthChecks().Return([]contracts.HealthCheckContract{database, cache})
+	checks = handler.healthChecks()
+	require.Len(t, checks, 2)
+	assert.Same(t, database, checks[0])
+}
+
+func TestHttpHandler_SetupRouter_SkipsProbes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buf := &bytes.Buffer{}
+	logger := zerolog.New(buf)
+	registry := prometheus.NewRegistry()
+	mockApp := mocks.NewMockAppContract(ctrl)
+	mockConfig := configmocks.NewMockConfigContract(ctrl)
+	mockConfig.EXPECT().GetDebug().Return(false).AnyTimes()
+	mockApp.EXPECT().GetConfigLoader().Return(&config.Loader{Config: mockConfig}).AnyTimes()
+	mockApp.EXPECT().SetGin(gomock.Any()).AnyTimes()
+	mockApp.EXPECT().GetValidator().Return(validator.New()).AnyTimes()
+	mockApp.EXPECT().GetMetricsRegistry().Return(registry).AnyTimes()
+	mockApp.EXPECT().GetLogger().Return(&logger).AnyTimes()
+
+	handler := &HttpHandler{App: mockApp}
+	r := handler.setupRouter(health.New())
+	r.GET("/users", func(ctx *gin.Context) { ctx.Status(http.StatusNoContent) })
+
+	for _, path := range []string{health.HealthPath, health.ReadinessPath, health.LivenessPath, metrics.Path, "/users"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.NotEmpty(t, w.Header().Get(timing.HeaderName), path)
+		assert.NotEmpty(t, w.Header().Get(middleware.RequestIDHeader), path)
+	}
+
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+	assert.Contains(t, buf.String(), `"path":"/users"`)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, metrics.Path, nil))
+	assert.Contains(t, w.Body.String(), `route="/users"`)
+	for _, path := range []string{health.HealthPath, health.ReadinessPath, health.LivenessPath, metrics.Path} {
+		assert.NotContains(t, w.Body.String(), `route="`+path+`"`)
+	}
+}
+
+// signalHelperEnv makes the test binary run the server of
+// TestHttpHandler_SecondSignalForcesExit instead of the tests.
+const signalHelperEnv = "PONODO_HTTP_SIGNAL_HELPER_PORT"
+
+func TestHttpHandler_SecondSignalForcesExit(t *testing.T) {
+	if port := os.Getenv(signalHelperEnv); port != "" {
+		runSignalHelper(t, port)
+		return
+	}
+
+	port := freePort(t)
+	helper := exec.Command(os.Args[0], "-test.run=^TestHttpHandler_SecondSignalForcesExit$")
+	helper.Env = append(os.Environ(), signalHelperEnv+"="+port)
+	require.NoError(t, helper.Start())
+	exited := make(chan error, 1)
+	go func() { exited <- helper.Wait() }()
+	defer func() { _ = helper.Process.Kill() }()
+
+	base := "http://127.0.0.1:" + port
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(base + health.LivenessPath)
+		if err != nil {
+			return false
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 10*time.Second, 20*time.Millisecond)
+	http.DefaultClient.CloseIdleConnections()
+
+	// An in-flight request keeps the graceful shutdown waiting.
+	go func() {
+		if resp, err := http.Get(base + "/slow"); err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	require.NoError(t, helper.Process.Signal(syscall.SIGINT))
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", "127.0.0.1:"+port)
+		if err != nil {
+			return true
+		}
+		_ = conn.Close()
+		return false
+	}, 10*time.Second, 20*time.Millisecond, "server did not start shutting down")
+
+	require.NoError(t, helper.Process.Signal(syscall.SIGINT))
+	select {
+	case err := <-exited:
+		var exitErr *exec.ExitError
+		require.ErrorAs(t, err, &exitErr)
+		status, ok := exitErr.Sys().(syscall.WaitStatus)
+		require.True(t, ok)
+		assert.True(t, status.Signaled())
+		assert.Equal(t, syscall.SIGINT, status.Signal())
+	case <-time.After(10 * time.Second):
+		t.Fatal("second interrupt did not terminate the process")
+	}
+}
+
+// runSignalHelper serves a slow route until the process is interrupted,
+// with a grace period longer than the parent test waits.
+func runSignalHelper(t *testing.T, port string) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gin.SetMode(gin.TestMode)
+	mockApp := mocks.NewMockAppContract(ctrl)
+	mockConfig := configmocks.NewMockConfigContract(ctrl)
+	mockConfig.EXPECT().GetDebug().Return(false).AnyTimes()
+	nop := zerolog.Nop()
+	mockApp.EXPECT().GetConfigLoader().Return(&config.Loader{Config: mockConfig}).AnyTimes()
+	mockApp.EXPECT().GetValidator().Return(validator.New()).AnyTimes()
+	mockApp.EXPECT().GetHealthChecks().Return(nil).AnyTimes()
+	mockApp.EXPECT().GetDb().Return(nil).AnyTimes()
+	mockApp.EXPECT().GetMetricsRegistry().Return(prometheus.NewRegistry()).AnyTimes()
+	mockApp.EXPECT().GetLogger().Return(&nop).AnyTimes()
+	mockApp.EXPECT().SetGin(gomock.Any()).Do(func(r *gin.Engine) {
+		r.GET("/slow", func(ctx *gin.Context) {
+			time.Sleep(time.Minute)
+			ctx.Status(http.StatusNoContent)
+		})
+	}).AnyTimes()
+
+	handler := &HttpHandler{App: mockApp}
+	cmd := newHttpCommand(handler, "--host", "127.0.0.1", "--port", port, "--shutdown-timeout", "1m")
+	_ = handler.RunE(cmd, nil)
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zerpto/ponodo/contracts"
+	"github.com/zerpto/ponodo/migration"
+)
+
+// MigrateHandler provides the migrate command and its up, down, status and
+// fresh subcommands. The migrations are the ones registered on the
+// application with AddMigrations and run against the default connection.
+type MigrateHandler struct {
+	App contracts.AppContract
+}
+
+// Command builds the migrate Cobra command with all of its subcommands.
+func (h *MigrateHandler) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Run database migrations.",
+		Long:  "Apply, roll back and inspect the versioned database migrations registered on the application.",
+		Example: `zerpto migrate up
+zerpto migrate down --steps 2
+zerpto migrate status`,
+	}
+
+	up := &cobra.Command{
+		Use:          "up",
+		Short:        "Apply all pending migrations.",
+		SilenceUsage: true,
+		RunE:         h.up,
+	}
+
+	down := &cobra.Command{
+		Use:          "down",
+		Short:        "Roll back the most recently applied migrations.",
+		SilenceUsage: true,
+		RunE:         h.down,
+	}
+	down.Flags().Int("steps", 1, "number of migrations to roll back")
+
+	status := &cobra.Command{
+		Use:          "status",
+		Short:        "Show which migrations have been applied.",
+		SilenceUsage: true,
+		RunE:         h.status,
+	}
+
+	fresh := &cobra.Command{
+		Use:          "fresh",
+		Short:        "Drop all tables and apply every migration from scratch.",
+		SilenceUsage: true,
+		RunE:         h.fresh,
+	}
+	fresh.Flags().Bool("force", false, "allow running in the production environment")
+
+	cmd.AddCommand(up, down, status, fresh)
+	return cmd
+}
+
+func (h *MigrateHandler) migrator() (*migration.Migrator, error) {
+	db := h.App.GetDb()
+	if db == nil {
+		return nil, errors.New("migrate: no database connection, call SetupBaseDependencies first")
+	}
+	return migration.NewMigrator(db, h.App.GetMigrations()), nil
+}
+
+func (h *MigrateHandler) up(cmd *cobra.Command, args []string) error {
+	migrator, err := h.migrator()
+	if err != nil {
+		return err
+	}
+
+	applied, err := migrator.Up(cmd.Context())
+	printMigrations(cmd, "Applied", applied)
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Nothing to migrate.")
+	}
+	return nil
+}
+
+func (h *MigrateHandler) down(cmd *cobra.Command, args []string) error {
+	steps, err := cmd.Flags().GetInt("steps")
+	if err != nil {
+		return err
+	}
+
+	migrator, err := h.migrator()
+	if err != nil {
+		return err
+	}
+
+	rolledBack, err := migrator.Down(cmd.Context(), steps)
+	printMigrations(cmd, "Rolled back", rolledBack)
+	if err != nil {
+		return err
+	}
+	if len(rolledBack) == 0 {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Nothing to roll back.")
+	}
+	return nil
+}
+
+func (h *MigrateHandler) status(cmd *cobra.Command, args []string) error {
+	migrator, err := h.migrator()
+	if err != nil {
+		return err
+	}
+
+	statuses, err := migrator.Status(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tBATCH\tAPPLIED AT")
+	for _, status := range statuses {
+		state, batch, appliedAt := "pending", "-", "-"
+		if status.Applied {
+			state = "applied"
+			batch = fmt.Sprint(status.Batch)
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", status.Version, status.Name, state, batch, appliedAt)
+	}
+	return w.Flush()
+}
+
+func (h *MigrateHandler) fresh(cmd *cobra.Command, args []string) error {
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		return err
+	}
+	if loader := h.App.GetConfigLoader(); !force && loader != nil && loader.Config != nil && loader.Config.GetEnv() == "production" {
+		return errors.New("migrate: refusing to drop all tables in production, use --force")
+	}
+
+	migrator, err := h.migrator()
+	if err != nil {
+		return err
+	}
+
+	applied, err := migrator.Fresh(cmd.Context())
+	printMigrations(cmd, "Applied", applied)
+	return err
+}
+
+func printMigrations(cmd *cobra.Command, verb string, migrations []migration.Migration) {
+	for _, m := range migrations {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s: %s %s\n", verb, m.Version, m.Name)
+	}
+}
+
+// NewMigrateCommand creates the migrate command for the given application.
+// It is added to the root command by cli.NewCli.
+func NewMigrateCommand(app contracts.AppContract) *cobra.Command {
+	handler := &MigrateHandler{
+		App: app,
+	}
+	return handler.Command()
+}
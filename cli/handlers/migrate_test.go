@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/zerpto/ponodo/config"
+	configmocks "github.com/zerpto/ponodo/config/contracts/mocks"
+	"github.com/zerpto/ponodo/contracts/mocks"
+	"github.com/zerpto/ponodo/migration"
+)
+
+func newMigrateTestApp(t *testing.T, ctrl *gomock.Controller, env string) (*mocks.MockAppContract, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "migrate.db")), &gorm.Config{})
+	require.NoError(t, err)
+
+	mockApp := mocks.NewMockAppContract(ctrl)
+	mockConfig := configmocks.NewMockConfigContract(ctrl)
+	mockConfig.EXPECT().GetEnv().Return(env).AnyTimes()
+	mockApp.EXPECT().GetConfigLoader().Return(&config.Loader{Config: mockConfig}).AnyTimes()
+	mockApp.EXPECT().GetDb().Return(db).AnyTimes()
+	mockApp.EXPECT().GetMigrations().Return([]migration.Migration{
+		{
+			Version: "20240101000000",
+			Name:    "create_users",
+			UpSQL:   "CREATE TABLE users (id INTEGER PRIMARY KEY)",
+			DownSQL: "DROP TABLE users",
+		},
+	}).AnyTimes()
+	return mockApp, db
+}
+
+func executeMigrate(app *mocks.MockAppContract, args ...string) (string, error) {
+	cmd := NewMigrateCommand(app)
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestNewMigrateCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := NewMigrateCommand(mocks.NewMockAppContract(ctrl))
+	assert.Equal(t, "migrate", cmd.Use)
+
+	var names []string
+	for _, sub := range cmd.Commands() {
+		names = append(names, sub.Name())
+	}
+	assert.ElementsMatch(t, []string{"up", "down", "status", "fresh"}, names)
+}
+
+func TestMigrateHandler_UpStatusDown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app, db := newMigrateTestApp(t, ctrl, "local")
+
+	out, err := executeMigrate(app, "up")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Applied: 20240101000000 create_users")
+	assert.True(t, db.Migrator().HasTable("users"))
+
+	out, err = executeMigrate(app, "status")
+	require.NoError(t, err)
+	assert.Contains(t, out, "applied")
+
+	out, err = executeMigrate(app, "down", "--steps", "1")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Rolled back: 20240101000000 create_users")
+	assert.False(t, db.Migrator().HasTable("users"))
+
+	out, err = executeMigrate(app, "down")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Nothing to roll back.")
+}
+
+func TestMigrateHandler_FreshRefusesProduction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app, _ := newMigrateTestApp(t, ctrl, "production")
+
+	_, err := executeMigrate(app, "fresh")
+	assert.Error(t, err)
+
+	out, err := executeMigrate(app, "fresh", "--force")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Applied: 20240101000000 create_users")
+}
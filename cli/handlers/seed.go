@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	clicontracts "github.com/zerpto/ponodo/cli/contracts"
+	"github.com/zerpto/ponodo/contracts"
+	"github.com/zerpto/ponodo/txcontext"
+	"github.com/zerpto/ponodo/utils"
+	"gorm.io/gorm"
+)
+
+// ErrSeederNotFound is returned when db:seed is asked to run a seeder that
+// has not been registered on the application.
+var ErrSeederNotFound = errors.New("seeder not found")
+
+// SeedHandler represents a CLI command handler for seeding the database.
+// It runs the seeders registered on the application in dependency order,
+// or a single seeder together with the seeders it depends on.
+type SeedHandler struct {
+	App contracts.AppContract
+}
+
+// Use returns the command name used to invoke this handler.
+// The optional name argument selects a single seeder to run.
+func (h *SeedHandler) Use() string {
+	return "db:seed [name]"
+}
+
+// Short returns a brief description of the seed command.
+// This description is displayed in the command help output
+// and provides a quick overview of the command's purpose.
+func (h *SeedHandler) Short() string {
+	return "Seed the database with records."
+}
+
+// Long returns a detailed description of the seed command.
+// This description is displayed in the extended help output
+// and provides comprehensive information about the command.
+func (h *SeedHandler) Long() string {
+	return "Run the registered database seeders in dependency order. When a seeder name is given, only that seeder and the seeders it depends on are run."
+}
+
+// Example returns an example usage string for the seed command.
+// This example demonstrates how to use the command with proper
+// syntax and arguments.
+func (h *SeedHandler) Example() string {
+	return `zerpto db:seed
+zerpto db:seed users`
+}
+
+// Run executes the seed command and logs the error when it fails. Commands
+// registered with AddCommand run RunE instead.
+func (h *SeedHandler) Run(cmd *cobra.Command, args []string) {
+	if err := h.RunE(cmd, args); err != nil {
+		log.Error().Err(err).Msg("failed to seed the database")
+	}
+}
+
+// RunE executes the seed command. Each seeder runs in its own transaction
+// on the default database connection, and the command stops at the first
+// seeder that fails. The transaction is stored in the context of the
+// connection passed to the seeder, so ponodo.DbFromContext and
+// ponodo.WithTransaction called with that context join it.
+func (h *SeedHandler) RunE(cmd *cobra.Command, args []string) error {
+	db := h.App.GetDb()
+	if db == nil {
+		return errors.New("db:seed: no database connection, call SetupBaseDependencies first")
+	}
+
+	var name string
+	if len(args) > 0 {
+		name = args[0]
+	}
+	seeders, err := h.seeders(name)
+	if err != nil {
+		return err
+	}
+
+	if len(seeders) == 0 {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Nothing to seed.")
+		return nil
+	}
+
+	db = db.WithContext(cmd.Context())
+	for _, seeder := range seeders {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			return seeder.Run(tx.WithContext(txcontext.With(tx.Statement.Context, tx)))
+		})
+		if err != nil {
+			return fmt.Errorf("seed %s: %w", seeder.Name(), err)
+		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Seeded: %s\n", seeder.Name())
+	}
+	return nil
+}
+
+// seeders returns the seeders to run in dependency order. An empty name
+// selects every registered seeder.
+func (h *SeedHandler) seeders(name string) ([]contracts.SeederContract, error) {
+	registered := h.App.GetSeeders()
+
+	names := make([]string, 0, len(registered))
+	dependencies := make(map[string][]string, len(registered))
+	byName := make(map[string]contracts.SeederContract, len(registered))
+	for _, seeder := range registered {
+		names = append(names, seeder.Name())
+		dependencies[seeder.Name()] = seeder.DependsOn()
+		byName[seeder.Name()] = seeder
+	}
+
+	sortedNames, err := utils.SortByDependencies(names, dependencies)
+	if err != nil {
+		return nil, fmt.Errorf("sort seeders: %w", err)
+	}
+
+	var selected map[string]bool
+	if name != "" {
+		if _, ok := byName[name]; !ok {
+			return nil, fmt.Errorf("%w: %q", ErrSeederNotFound, name)
+		}
+		selected = make(map[string]bool)
+		var visit func(name string)
+		visit = func(name string) {
+			if selected[name] {
+				return
+			}
+			selected[name] = true
+			for _, dependency := range dependencies[name] {
+				visit(dependency)
+			}
+		}
+		visit(name)
+	}
+
+	seeders := make([]contracts.SeederContract, 0, len(sortedNames))
+	for _, sortedName := range sortedNames {
+		if selected == nil || selected[sortedName] {
+			seeders = append(seeders, byName[sortedName])
+		}
+	}
+	return seeders, nil
+}
+
+// NewSeedHandler creates a new seed command handler instance. It matches
+// the signature expected by AddCommand, so it can be registered with
+// app.AddCommand(handlers.NewSeedHandler).
+func NewSeedHandler(app contracts.AppContract) clicontracts.CommandContract {
+	return &SeedHandler{
+		App: app,
+	}
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/zerpto/ponodo/contracts"
+	"github.com/zerpto/ponodo/contracts/mocks"
+	"github.com/zerpto/ponodo/txcontext"
+)
+
+type seedTestRecord struct {
+	ID     uint
+	Seeder string
+}
+
+type testSeeder struct {
+	name      string
+	dependsOn []string
+	err       error
+}
+
+func (s *testSeeder) Name() string        { return s.name }
+func (s *testSeeder) DependsOn() []string { return s.dependsOn }
+
+func (s *testSeeder) Run(db *gorm.DB) error {
+	if err := db.Create(&seedTestRecord{Seeder: s.name}).Error; err != nil {
+		return err
+	}
+	return s.err
+}
+
+// contextSeeder records the transaction stored in the context of the
+// connection it is run with.
+type contextSeeder struct {
+	tx *gorm.DB
+}
+
+func (s *contextSeeder) Name() string        { return "context" }
+func (s *contextSeeder) DependsOn() []string { return nil }
+
+func (s *contextSeeder) Run(db *gorm.DB) error {
+	s.tx = txcontext.From(db.Statement.Context)
+	return nil
+}
+
+func newSeedTestApp(t *testing.T, ctrl *gomock.Controller, seeders ...contracts.SeederContract) (*mocks.MockAppContract, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "seed.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&seedTestRecord{}))
+
+	mockApp := mocks.NewMockAppContract(ctrl)
+	mockApp.EXPECT().GetDb().Return(db).AnyTimes()
+	mockApp.EXPECT().GetSeeders().Return(seeders).AnyTimes()
+	return mockApp, db
+}
+
+func executeSeed(app *mocks.MockAppContract, args ...string) (string, error) {
+	handler := &SeedHandler{App: app}
+	out := &bytes.Buffer{}
+	cmd := &cobra.Command{}
+	cmd.SetOut(out)
+
+	err := handler.RunE(cmd, args)
+	return out.String(), err
+}
+
+func seededNames(t *testing.T, db *gorm.DB) []string {
+	var records []seedTestRecord
+	require.NoError(t, db.Order("id").Find(&records).Error)
+
+	names := make([]string, 0, len(records))
+	for _, record := range records {
+		names = append(names, record.Seeder)
+	}
+	return names
+}
+
+func TestSeedHandler_Use(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewSeedHandler(mocks.NewMockAppContract(ctrl))
+	assert.Equal(t, "db:seed [name]", handler.Use())
+}
+
+func TestSeedHandler_RunsAllInDependencyOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app, db := newSeedTestApp(t, ctrl,
+		&testSeeder{name: "posts", dependsOn: []string{"users"}},
+		&testSeeder{name: "users", dependsOn: []string{"roles"}},
+		&testSeeder{name: "roles"},
+	)
+
+	out, err := executeSeed(app)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"roles", "users", "posts"}, seededNames(t, db))
+	assert.Contains(t, out, "Seeded: posts")
+}
+
+func TestSeedHandler_RunsNamedSeederWithDependencies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app, db := newSeedTestApp(t, ctrl,
+		&testSeeder{name: "posts", dependsOn: []string{"users"}},
+		&testSeeder{name: "users"},
+		&testSeeder{name: "products"},
+	)
+
+	_, err := executeSeed(app, "posts")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"users", "posts"}, seededNames(t, db))
+}
+
+func TestSeedHandler_UnknownSeeder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app, _ := newSeedTestApp(t, ctrl, &testSeeder{name: "users"})
+
+	_, err := executeSeed(app, "posts")
+	assert.True(t, errors.Is(err, ErrSeederNotFound))
+}
+
+func TestSeedHandler_FailingSeederRollsBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app, db := newSeedTestApp(t, ctrl,
+		&testSeeder{name: "users"},
+		&testSeeder{name: "posts", dependsOn: []string{"users"}, err: errors.New("boom")},
+	)
+
+	_, err := executeSeed(app)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "seed posts")
+	assert.Equal(t, []string{"users"}, seededNames(t, db))
+}
+
+func TestSeedHandler_WithoutDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockApp := mocks.NewMockAppContract(ctrl)
+	mockApp.EXPECT().GetDb().Return(nil)
+
+	_, err := executeSeed(mockApp)
+	assert.Error(t, err)
+}
+
+func TestSeedHandler_StoresTransactionInContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	seeder := &contextSeeder{}
+	app, _ := newSeedTestApp(t, ctrl, seeder)
+
+	_, err := executeSeed(app)
+	require.NoError(t, err)
+	require.NotNil(t, seeder.tx)
+	assert.IsType(t, &sql.Tx{}, seeder.tx.Statement.ConnPool)
+}
@@ -1,9 +1,16 @@
 package contracts
 
+import "time"
+
 // DbConfigContract defines the interface for database configuration access.
 // Implementations of this interface provide methods to retrieve database
 // connection parameters such as host, port, user, password, and database name.
 //
+// Every other setting is optional. A configuration opts into it by also
+// implementing DbDriverContract, DbPoolContract, DbTLSContract,
+// DbReplicasContract or DbSlowQueryContract; settings it does not
+// implement keep their defaults.
+//
 //go:generate mockgen -source=$GOFILE -destination=./mocks/mock_db_contract.go -package=mocks
 type DbConfigContract interface {
 	GetHost() string
@@ -12,3 +19,43 @@ type DbConfigContract interface {
 	GetPassword() string
 	GetDatabase() string
 }
+
+// DbDriverContract is an optional interface for configurations naming the
+// database driver and its driver specific options. The driver defaults to
+// postgres.
+type DbDriverContract interface {
+	GetDriver() string
+	GetOptions() map[string]string
+}
+
+// DbPoolContract is an optional interface for configurations tuning the
+// connection pool. A zero value keeps the database/sql default.
+type DbPoolContract interface {
+	GetMaxOpenConns() int
+	GetMaxIdleConns() int
+	GetConnMaxLifetime() time.Duration
+	GetConnMaxIdleTime() time.Duration
+}
+
+// DbTLSContract is an optional interface for configurations securing the
+// connection with TLS. An empty SSL mode keeps the driver default.
+type DbTLSContract interface {
+	GetSSLMode() string
+	GetSSLRootCert() string
+	GetSSLCert() string
+	GetSSLKey() string
+}
+
+// DbReplicasContract is an optional interface for configurations routing
+// reads to replicas.
+type DbReplicasContract interface {
+	GetReplicas() []string
+	GetReplicaPolicy() string
+}
+
+// DbSlowQueryContract is an optional interface for configurations setting
+// the duration above which queries are logged as slow. A zero threshold
+// disables slow query logging.
+type DbSlowQueryContract interface {
+	GetSlowThreshold() time.Duration
+}
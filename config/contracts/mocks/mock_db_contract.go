@@ -11,6 +11,7 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -108,3 +109,305 @@ func (mr *MockDbConfigContractMockRecorder) GetUser() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockDbConfigContract)(nil).GetUser))
 }
+
+// MockDbDriverContract is a mock of DbDriverContract interface.
+type MockDbDriverContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockDbDriverContractMockRecorder
+	isgomock struct{}
+}
+
+// MockDbDriverContractMockRecorder is the mock recorder for MockDbDriverContract.
+type MockDbDriverContractMockRecorder struct {
+	mock *MockDbDriverContract
+}
+
+// NewMockDbDriverContract creates a new mock instance.
+func NewMockDbDriverContract(ctrl *gomock.Controller) *MockDbDriverContract {
+	mock := &MockDbDriverContract{ctrl: ctrl}
+	mock.recorder = &MockDbDriverContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDbDriverContract) EXPECT() *MockDbDriverContractMockRecorder {
+	return m.recorder
+}
+
+// GetDriver mocks base method.
+func (m *MockDbDriverContract) GetDriver() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDriver")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetDriver indicates an expected call of GetDriver.
+func (mr *MockDbDriverContractMockRecorder) GetDriver() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDriver", reflect.TypeOf((*MockDbDriverContract)(nil).GetDriver))
+}
+
+// GetOptions mocks base method.
+func (m *MockDbDriverContract) GetOptions() map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOptions")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// GetOptions indicates an expected call of GetOptions.
+func (mr *MockDbDriverContractMockRecorder) GetOptions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOptions", reflect.TypeOf((*MockDbDriverContract)(nil).GetOptions))
+}
+
+// MockDbPoolContract is a mock of DbPoolContract interface.
+type MockDbPoolContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockDbPoolContractMockRecorder
+	isgomock struct{}
+}
+
+// MockDbPoolContractMockRecorder is the mock recorder for MockDbPoolContract.
+type MockDbPoolContractMockRecorder struct {
+	mock *MockDbPoolContract
+}
+
+// NewMockDbPoolContract creates a new mock instance.
+func NewMockDbPoolContract(ctrl *gomock.Controller) *MockDbPoolContract {
+	mock := &MockDbPoolContract{ctrl: ctrl}
+	mock.recorder = &MockDbPoolContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDbPoolContract) EXPECT() *MockDbPoolContractMockRecorder {
+	return m.recorder
+}
+
+// GetConnMaxIdleTime mocks base method.
+func (m *MockDbPoolContract) GetConnMaxIdleTime() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConnMaxIdleTime")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetConnMaxIdleTime indicates an expected call of GetConnMaxIdleTime.
+func (mr *MockDbPoolContractMockRecorder) GetConnMaxIdleTime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnMaxIdleTime", reflect.TypeOf((*MockDbPoolContract)(nil).GetConnMaxIdleTime))
+}
+
+// GetConnMaxLifetime mocks base method.
+func (m *MockDbPoolContract) GetConnMaxLifetime() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConnMaxLifetime")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetConnMaxLifetime indicates an expected call of GetConnMaxLifetime.
+func (mr *MockDbPoolContractMockRecorder) GetConnMaxLifetime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnMaxLifetime", reflect.TypeOf((*MockDbPoolContract)(nil).GetConnMaxLifetime))
+}
+
+// GetMaxIdleConns mocks base method.
+func (m *MockDbPoolContract) GetMaxIdleConns() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxIdleConns")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetMaxIdleConns indicates an expected call of GetMaxIdleConns.
+func (mr *MockDbPoolContractMockRecorder) GetMaxIdleConns() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxIdleConns", reflect.TypeOf((*MockDbPoolContract)(nil).GetMaxIdleConns))
+}
+
+// GetMaxOpenConns mocks base method.
+func (m *MockDbPoolContract) GetMaxOpenConns() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxOpenConns")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetMaxOpenConns indicates an expected call of GetMaxOpenConns.
+func (mr *MockDbPoolContractMockRecorder) GetMaxOpenConns() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxOpenConns", reflect.TypeOf((*MockDbPoolContract)(nil).GetMaxOpenConns))
+}
+
+// MockDbTLSContract is a mock of DbTLSContract interface.
+type MockDbTLSContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockDbTLSContractMockRecorder
+	isgomock struct{}
+}
+
+// MockDbTLSContractMockRecorder is the mock recorder for MockDbTLSContract.
+type MockDbTLSContractMockRecorder struct {
+	mock *MockDbTLSContract
+}
+
+// NewMockDbTLSContract creates a new mock instance.
+func NewMockDbTLSContract(ctrl *gomock.Controller) *MockDbTLSContract {
+	mock := &MockDbTLSContract{ctrl: ctrl}
+	mock.recorder = &MockDbTLSContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDbTLSContract) EXPECT() *MockDbTLSContractMockRecorder {
+	return m.recorder
+}
+
+// GetSSLCert mocks base method.
+func (m *MockDbTLSContract) GetSSLCert() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSSLCert")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetSSLCert indicates an expected call of GetSSLCert.
+func (mr *MockDbTLSContractMockRecorder) GetSSLCert() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSSLCert", reflect.TypeOf((*MockDbTLSContract)(nil).GetSSLCert))
+}
+
+// GetSSLKey mocks base method.
+func (m *MockDbTLSContract) GetSSLKey() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSSLKey")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetSSLKey indicates an expected call of GetSSLKey.
+func (mr *MockDbTLSContractMockRecorder) GetSSLKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSSLKey", reflect.TypeOf((*MockDbTLSContract)(nil).GetSSLKey))
+}
+
+// GetSSLMode mocks base method.
+func (m *MockDbTLSContract) GetSSLMode() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSSLMode")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetSSLMode indicates an expected call of GetSSLMode.
+func (mr *MockDbTLSContractMockRecorder) GetSSLMode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSSLMode", reflect.TypeOf((*MockDbTLSContract)(nil).GetSSLMode))
+}
+
+// GetSSLRootCert mocks base method.
+func (m *MockDbTLSContract) GetSSLRootCert() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSSLRootCert")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetSSLRootCert indicates an expected call of GetSSLRootCert.
+func (mr *MockDbTLSContractMockRecorder) GetSSLRootCert() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSSLRootCert", reflect.TypeOf((*MockDbTLSContract)(nil).GetSSLRootCert))
+}
+
+// MockDbReplicasContract is a mock of DbReplicasContract interface.
+type MockDbReplicasContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockDbReplicasContractMockRecorder
+	isgomock struct{}
+}
+
+// MockDbReplicasContractMockRecorder is the mock recorder for MockDbReplicasContract.
+type MockDbReplicasContractMockRecorder struct {
+	mock *MockDbReplicasContract
+}
+
+// NewMockDbReplicasContract creates a new mock instance.
+func NewMockDbReplicasContract(ctrl *gomock.Controller) *MockDbReplicasContract {
+	mock := &MockDbReplicasContract{ctrl: ctrl}
+	mock.recorder = &MockDbReplicasContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDbReplicasContract) EXPECT() *MockDbReplicasContractMockRecorder {
+	return m.recorder
+}
+
+// GetReplicaPolicy mocks base method.
+func (m *MockDbReplicasContract) GetReplicaPolicy() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReplicaPolicy")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetReplicaPolicy indicates an expected call of GetReplicaPolicy.
+func (mr *MockDbReplicasContractMockRecorder) GetReplicaPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplicaPolicy", reflect.TypeOf((*MockDbReplicasContract)(nil).GetReplicaPolicy))
+}
+
+// GetReplicas mocks base method.
+func (m *MockDbReplicasContract) GetReplicas() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReplicas")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetReplicas indicates an expected call of GetReplicas.
+func (mr *MockDbReplicasContractMockRecorder) GetReplicas() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplicas", reflect.TypeOf((*MockDbReplicasContract)(nil).GetReplicas))
+}
+
+// MockDbSlowQueryContract is a mock of DbSlowQueryContract interface.
+type MockDbSlowQueryContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockDbSlowQueryContractMockRecorder
+	isgomock struct{}
+}
+
+// MockDbSlowQueryContractMockRecorder is the mock recorder for MockDbSlowQueryContract.
+type MockDbSlowQueryContractMockRecorder struct {
+	mock *MockDbSlowQueryContract
+}
+
+// NewMockDbSlowQueryContract creates a new mock instance.
+func NewMockDbSlowQueryContract(ctrl *gomock.Controller) *MockDbSlowQueryContract {
+	mock := &MockDbSlowQueryContract{ctrl: ctrl}
+	mock.recorder = &MockDbSlowQueryContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDbSlowQueryContract) EXPECT() *MockDbSlowQueryContractMockRecorder {
+	return m.recorder
+}
+
+// GetSlowThreshold mocks base method.
+func (m *MockDbSlowQueryContract) GetSlowThreshold() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlowThreshold")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetSlowThreshold indicates an expected call of GetSlowThreshold.
+func (mr *MockDbSlowQueryContractMockRecorder) GetSlowThreshold() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlowThreshold", reflect.TypeOf((*MockDbSlowQueryContract)(nil).GetSlowThreshold))
+}
@@ -0,0 +1,215 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultDbSlowThreshold is the query duration above which a query is
+// logged as slow when DB_SLOW_THRESHOLD is not set.
+const DefaultDbSlowThreshold = 200 * time.Millisecond
+
+// defaultDbConnection is the name under which the application stores the
+// primary connection, so it cannot name an additional connection.
+const defaultDbConnection = "default"
+
+// DbConfig is the default implementation of the DbConfigContract interface.
+// It is populated by the Loader from configuration keys sharing a common
+// prefix, for example DB_HOST and DB_PORT for the "db" prefix.
+type DbConfig struct {
+	Driver   string
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Database string
+	Options  map[string]string
+
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	SSLMode     string
+	SSLRootCert string
+	SSLCert     string
+	SSLKey      string
+
+	Replicas      []string
+	ReplicaPolicy string
+
+	SlowThreshold time.Duration
+}
+
+// GetDriver returns the database driver name, such as postgres or mysql.
+func (c *DbConfig) GetDriver() string { return c.Driver }
+
+// GetHost returns the database host.
+func (c *DbConfig) GetHost() string { return c.Host }
+
+// GetPort returns the database port.
+func (c *DbConfig) GetPort() string { return c.Port }
+
+// GetUser returns the database user.
+func (c *DbConfig) GetUser() string { return c.User }
+
+// GetPassword returns the database password.
+func (c *DbConfig) GetPassword() string { return c.Password }
+
+// GetDatabase returns the database name, or the file path for sqlite.
+func (c *DbConfig) GetDatabase() string { return c.Database }
+
+// GetOptions returns the driver specific connection options.
+func (c *DbConfig) GetOptions() map[string]string { return c.Options }
+
+// GetMaxOpenConns returns the maximum number of open connections.
+func (c *DbConfig) GetMaxOpenConns() int { return c.MaxOpenConns }
+
+// GetMaxIdleConns returns the maximum number of idle connections.
+func (c *DbConfig) GetMaxIdleConns() int { return c.MaxIdleConns }
+
+// GetConnMaxLifetime returns the maximum lifetime of a connection.
+func (c *DbConfig) GetConnMaxLifetime() time.Duration { return c.ConnMaxLifetime }
+
+// GetConnMaxIdleTime returns the maximum idle time of a connection.
+func (c *DbConfig) GetConnMaxIdleTime() time.Duration { return c.ConnMaxIdleTime }
+
+// GetSSLMode returns the TLS mode used to connect to the database.
+func (c *DbConfig) GetSSLMode() string { return c.SSLMode }
+
+// GetSSLRootCert returns the path of the CA certificate file.
+func (c *DbConfig) GetSSLRootCert() string { return c.SSLRootCert }
+
+// GetSSLCert returns the path of the client certificate file.
+func (c *DbConfig) GetSSLCert() string { return c.SSLCert }
+
+// GetSSLKey returns the path of the client key file.
+func (c *DbConfig) GetSSLKey() string { return c.SSLKey }
+
+// GetReplicas returns the read replica hosts, each as host or host:port.
+func (c *DbConfig) GetReplicas() []string { return c.Replicas }
+
+// GetReplicaPolicy returns the replica selection policy, random or round_robin.
+func (c *DbConfig) GetReplicaPolicy() string { return c.ReplicaPolicy }
+
+// GetSlowThreshold returns the duration above which a query is logged as
+// slow. Zero disables slow query logging.
+func (c *DbConfig) GetSlowThreshold() time.Duration { return c.SlowThreshold }
+
+// GetDbConfig builds a database configuration from the keys sharing the
+// given prefix. With the prefix "db" it reads DB_DRIVER, DB_HOST, DB_PORT,
+// DB_USER, DB_PASSWORD, DB_DATABASE, DB_OPTIONS (a query string such as
+// "application_name=api&connect_timeout=5"), DB_MAX_OPEN_CONNS,
+// DB_MAX_IDLE_CONNS, DB_CONN_MAX_LIFETIME, DB_CONN_MAX_IDLE_TIME,
+// DB_SSL_MODE, DB_SSL_ROOT_CERT, DB_SSL_CERT, DB_SSL_KEY, DB_REPLICAS
+// (a comma separated host list), DB_REPLICA_POLICY and DB_SLOW_THRESHOLD,
+// which defaults to DefaultDbSlowThreshold.
+func (c *Loader) GetDbConfig(prefix string) (*DbConfig, error) {
+	key := func(name string) string {
+		return strings.ToLower(prefix + "_" + name)
+	}
+
+	options, err := parseOptions(viper.GetString(key("options")))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrConfigInvalid, strings.ToUpper(key("options")), err)
+	}
+
+	connMaxLifetime, err := parseDuration(key("conn_max_lifetime"))
+	if err != nil {
+		return nil, err
+	}
+	connMaxIdleTime, err := parseDuration(key("conn_max_idle_time"))
+	if err != nil {
+		return nil, err
+	}
+	slowThreshold, err := parseDurationOr(key("slow_threshold"), DefaultDbSlowThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DbConfig{
+		Driver:          viper.GetString(key("driver")),
+		Host:            viper.GetString(key("host")),
+		Port:            viper.GetString(key("port")),
+		User:            viper.GetString(key("user")),
+		Password:        viper.GetString(key("password")),
+		Database:        viper.GetString(key("database")),
+		Options:         options,
+		MaxOpenConns:    viper.GetInt(key("max_open_conns")),
+		MaxIdleConns:    viper.GetInt(key("max_idle_conns")),
+		ConnMaxLifetime: connMaxLifetime,
+		ConnMaxIdleTime: connMaxIdleTime,
+		SSLMode:         viper.GetString(key("ssl_mode")),
+		SSLRootCert:     viper.GetString(key("ssl_root_cert")),
+		SSLCert:         viper.GetString(key("ssl_cert")),
+		SSLKey:          viper.GetString(key("ssl_key")),
+		Replicas:        splitList(viper.GetString(key("replicas"))),
+		ReplicaPolicy:   viper.GetString(key("replica_policy")),
+		SlowThreshold:   slowThreshold,
+	}, nil
+}
+
+// GetDbConnections returns the named database connections listed in
+// DB_CONNECTIONS, a comma separated list of names. Each connection is read
+// with GetDbConfig using the prefix DB_<NAME>, so the "analytics" connection
+// is configured through DB_ANALYTICS_HOST, DB_ANALYTICS_PORT and so on. The
+// name "default" is reserved for the primary connection and returns an
+// error wrapping ErrConfigInvalid.
+func (c *Loader) GetDbConnections() (map[string]*DbConfig, error) {
+	connections := make(map[string]*DbConfig)
+	for _, name := range splitList(viper.GetString("db_connections")) {
+		name = strings.ToLower(name)
+		if name == defaultDbConnection {
+			return nil, fmt.Errorf("%w: DB_CONNECTIONS: %q is reserved for the primary connection", ErrConfigInvalid, name)
+		}
+		dbConfig, err := c.GetDbConfig("db_" + name)
+		if err != nil {
+			return nil, err
+		}
+		connections[name] = dbConfig
+	}
+	return connections, nil
+}
+
+func parseOptions(raw string) (map[string]string, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		return nil, err
+	}
+	options := make(map[string]string, len(values))
+	for name := range values {
+		options[name] = values.Get(name)
+	}
+	return options, nil
+}
+
+func parseDuration(key string) (time.Duration, error) {
+	raw := viper.GetString(key)
+	if raw == "" {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %w", ErrConfigInvalid, strings.ToUpper(key), err)
+	}
+	return duration, nil
+}
+
+func splitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
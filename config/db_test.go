@@ -0,0 +1,101 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zerpto/ponodo/config/contracts"
+)
+
+var (
+	_ contracts.DbConfigContract    = (*DbConfig)(nil)
+	_ contracts.DbDriverContract    = (*DbConfig)(nil)
+	_ contracts.DbPoolContract      = (*DbConfig)(nil)
+	_ contracts.DbTLSContract       = (*DbConfig)(nil)
+	_ contracts.DbReplicasContract  = (*DbConfig)(nil)
+	_ contracts.DbSlowQueryContract = (*DbConfig)(nil)
+)
+
+func TestLoader_GetDbConfig(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("db_driver", "mysql")
+	viper.Set("db_host", "primary.local")
+	viper.Set("db_port", "3306")
+	viper.Set("db_user", "app")
+	viper.Set("db_password", "secret")
+	viper.Set("db_database", "shop")
+	viper.Set("db_options", "timeout=5s&readTimeout=2s")
+	viper.Set("db_max_open_conns", "20")
+	viper.Set("db_conn_max_lifetime", "5m")
+	viper.Set("db_ssl_mode", "verify-full")
+	viper.Set("db_replicas", "replica-1:3306, replica-2")
+	viper.Set("db_replica_policy", "round_robin")
+	viper.Set("db_slow_threshold", "1s")
+
+	loader := &Loader{}
+	dbConfig, err := loader.GetDbConfig("db")
+	require.NoError(t, err)
+
+	assert.Equal(t, "mysql", dbConfig.GetDriver())
+	assert.Equal(t, "primary.local", dbConfig.GetHost())
+	assert.Equal(t, "3306", dbConfig.GetPort())
+	assert.Equal(t, "app", dbConfig.GetUser())
+	assert.Equal(t, "secret", dbConfig.GetPassword())
+	assert.Equal(t, "shop", dbConfig.GetDatabase())
+	assert.Equal(t, map[string]string{"timeout": "5s", "readTimeout": "2s"}, dbConfig.GetOptions())
+	assert.Equal(t, 20, dbConfig.GetMaxOpenConns())
+	assert.Equal(t, 0, dbConfig.GetMaxIdleConns())
+	assert.Equal(t, 5*time.Minute, dbConfig.GetConnMaxLifetime())
+	assert.Equal(t, "verify-full", dbConfig.GetSSLMode())
+	assert.Equal(t, []string{"replica-1:3306", "replica-2"}, dbConfig.GetReplicas())
+	assert.Equal(t, "round_robin", dbConfig.GetReplicaPolicy())
+	assert.Equal(t, time.Second, dbConfig.GetSlowThreshold())
+}
+
+func TestLoader_GetDbConfig_InvalidDuration(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("db_conn_max_idle_time", "forever")
+
+	loader := &Loader{}
+	_, err := loader.GetDbConfig("db")
+	if !errors.Is(err, ErrConfigInvalid) {
+		t.Errorf("GetDbConfig error = %v, want ErrConfigInvalid", err)
+	}
+}
+
+func TestLoader_GetDbConnections(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("db_connections", "analytics, Reporting")
+	viper.Set("db_analytics_host", "analytics.local")
+	viper.Set("db_reporting_driver", "sqlite")
+
+	loader := &Loader{}
+	connections, err := loader.GetDbConnections()
+	require.NoError(t, err)
+
+	require.Len(t, connections, 2)
+	assert.Equal(t, "analytics.local", connections["analytics"].GetHost())
+	assert.Equal(t, "sqlite", connections["reporting"].GetDriver())
+	assert.Equal(t, DefaultDbSlowThreshold, connections["reporting"].GetSlowThreshold())
+}
+
+func TestLoader_GetDbConnections_ReservedName(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("db_connections", "analytics, Default")
+
+	_, err := (&Loader{}).GetDbConnections()
+	assert.ErrorIs(t, err, ErrConfigInvalid)
+}
@@ -0,0 +1,13 @@
+package config
+
+import "errors"
+
+var (
+	// ErrConfigMissing is returned when the configuration file cannot be
+	// found or when no configuration has been bound to the loader.
+	ErrConfigMissing = errors.New("config: configuration missing")
+
+	// ErrConfigInvalid is returned when the configuration file exists but
+	// cannot be parsed.
+	ErrConfigInvalid = errors.New("config: configuration invalid")
+)
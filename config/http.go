@@ -0,0 +1,128 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Defaults used by GetHttpConfig for the keys that are not set.
+const (
+	DefaultHttpPort              = "8080"
+	DefaultHttpReadTimeout       = 30 * time.Second
+	DefaultHttpReadHeaderTimeout = 10 * time.Second
+	DefaultHttpWriteTimeout      = 30 * time.Second
+	DefaultHttpIdleTimeout       = 120 * time.Second
+	DefaultHttpShutdownTimeout   = 30 * time.Second
+	DefaultHttpMaxHeaderBytes    = 1 << 20
+)
+
+// Serving modes of the HTTP server.
+const (
+	// HttpModeHTTP1 serves HTTP/1.1, and HTTP/2 when TLS is enabled.
+	HttpModeHTTP1 = "http1"
+
+	// HttpModeH2C additionally serves HTTP/2 over cleartext connections.
+	HttpModeH2C = "h2c"
+
+	// HttpModeHTTP3 additionally serves HTTP/3 over QUIC and requires TLS.
+	HttpModeHTTP3 = "http3"
+)
+
+// HttpConfig holds the settings of the HTTP server started by the http
+// command. TLS is enabled when both TLSCert and TLSKey are set.
+type HttpConfig struct {
+	Host string
+	Port string
+	Mode string
+
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	ShutdownTimeout   time.Duration
+	MaxHeaderBytes    int
+
+	TLSCert string
+	TLSKey  string
+}
+
+// Addr returns the address the server listens on, in host:port form.
+func (c *HttpConfig) Addr() string {
+	return net.JoinHostPort(c.Host, c.Port)
+}
+
+// TLSEnabled reports whether a certificate and key have been configured.
+func (c *HttpConfig) TLSEnabled() bool {
+	return c.TLSCert != "" && c.TLSKey != ""
+}
+
+// Validate returns an error wrapping ErrConfigInvalid when only one of the
+// TLS certificate and key is set, which would otherwise silently serve
+// plain HTTP.
+func (c *HttpConfig) Validate() error {
+	if (c.TLSCert == "") != (c.TLSKey == "") {
+		return fmt.Errorf("%w: HTTP_TLS_CERT and HTTP_TLS_KEY must be set together", ErrConfigInvalid)
+	}
+	return nil
+}
+
+// GetHttpConfig builds the HTTP server configuration from HTTP_HOST,
+// HTTP_PORT, HTTP_MODE, HTTP_READ_TIMEOUT, HTTP_READ_HEADER_TIMEOUT,
+// HTTP_WRITE_TIMEOUT, HTTP_IDLE_TIMEOUT, HTTP_SHUTDOWN_TIMEOUT,
+// HTTP_MAX_HEADER_BYTES, HTTP_TLS_CERT and HTTP_TLS_KEY. Durations use the
+// time.ParseDuration format and unset keys fall back to the defaults. An
+// error wrapping ErrConfigInvalid is returned when only one of the TLS
+// certificate and key is set.
+func (c *Loader) GetHttpConfig() (*HttpConfig, error) {
+	httpConfig := &HttpConfig{
+		Host:           viper.GetString("http_host"),
+		Port:           viper.GetString("http_port"),
+		Mode:           strings.ToLower(viper.GetString("http_mode")),
+		MaxHeaderBytes: viper.GetInt("http_max_header_bytes"),
+		TLSCert:        viper.GetString("http_tls_cert"),
+		TLSKey:         viper.GetString("http_tls_key"),
+	}
+	if httpConfig.Port == "" {
+		httpConfig.Port = DefaultHttpPort
+	}
+	if httpConfig.Mode == "" {
+		httpConfig.Mode = HttpModeHTTP1
+	}
+	if httpConfig.MaxHeaderBytes <= 0 {
+		httpConfig.MaxHeaderBytes = DefaultHttpMaxHeaderBytes
+	}
+
+	var err error
+	if httpConfig.ReadTimeout, err = parseDurationOr("http_read_timeout", DefaultHttpReadTimeout); err != nil {
+		return nil, err
+	}
+	if httpConfig.ReadHeaderTimeout, err = parseDurationOr("http_read_header_timeout", DefaultHttpReadHeaderTimeout); err != nil {
+		return nil, err
+	}
+	if httpConfig.WriteTimeout, err = parseDurationOr("http_write_timeout", DefaultHttpWriteTimeout); err != nil {
+		return nil, err
+	}
+	if httpConfig.IdleTimeout, err = parseDurationOr("http_idle_timeout", DefaultHttpIdleTimeout); err != nil {
+		return nil, err
+	}
+	if httpConfig.ShutdownTimeout, err = parseDurationOr("http_shutdown_timeout", DefaultHttpShutdownTimeout); err != nil {
+		return nil, err
+	}
+	if err := httpConfig.Validate(); err != nil {
+		return nil, err
+	}
+	return httpConfig, nil
+}
+
+// parseDurationOr parses the duration stored under the key and returns the
+// fallback when the key is not set.
+func parseDurationOr(key string, fallback time.Duration) (time.Duration, error) {
+	if viper.GetString(key) == "" {
+		return fallback, nil
+	}
+	return parseDuration(key)
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoader_GetHttpConfig_Defaults(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	loader := &Loader{}
+	httpConfig, err := loader.GetHttpConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, ":8080", httpConfig.Addr())
+	assert.Equal(t, HttpModeHTTP1, httpConfig.Mode)
+	assert.Equal(t, DefaultHttpReadTimeout, httpConfig.ReadTimeout)
+	assert.Equal(t, DefaultHttpReadHeaderTimeout, httpConfig.ReadHeaderTimeout)
+	assert.Equal(t, DefaultHttpWriteTimeout, httpConfig.WriteTimeout)
+	assert.Equal(t, DefaultHttpIdleTimeout, httpConfig.IdleTimeout)
+	assert.Equal(t, DefaultHttpShutdownTimeout, httpConfig.ShutdownTimeout)
+	assert.Equal(t, DefaultHttpMaxHeaderBytes, httpConfig.MaxHeaderBytes)
+	assert.False(t, httpConfig.TLSEnabled())
+}
+
+func TestLoader_GetHttpConfig(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("http_host", "127.0.0.1")
+	viper.Set("http_port", "9000")
+	viper.Set("http_mode", "H2C")
+	viper.Set("http_read_timeout", "5s")
+	viper.Set("http_write_timeout", "1m")
+	viper.Set("http_shutdown_timeout", "0s")
+	viper.Set("http_max_header_bytes", "4096")
+	viper.Set("http_tls_cert", "/etc/tls/tls.crt")
+	viper.Set("http_tls_key", "/etc/tls/tls.key")
+
+	loader := &Loader{}
+	httpConfig, err := loader.GetHttpConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, "127.0.0.1:9000", httpConfig.Addr())
+	assert.Equal(t, HttpModeH2C, httpConfig.Mode)
+	assert.Equal(t, 5*time.Second, httpConfig.ReadTimeout)
+	assert.Equal(t, time.Minute, httpConfig.WriteTimeout)
+	assert.Equal(t, time.Duration(0), httpConfig.ShutdownTimeout)
+	assert.Equal(t, 4096, httpConfig.MaxHeaderBytes)
+	assert.True(t, httpConfig.TLSEnabled())
+}
+
+func TestLoader_GetHttpConfig_InvalidDuration(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("http_idle_timeout", "soon")
+
+	loader := &Loader{}
+	_, err := loader.GetHttpConfig()
+	assert.True(t, errors.Is(err, ErrConfigInvalid))
+}
+
+func TestLoader_GetHttpConfig_PartialTLS(t *testing.T) {
+	for _, key := range []string{"http_tls_cert", "http_tls_key"} {
+		t.Run(key, func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+
+			viper.Set(key, "/etc/tls/server.pem")
+
+			loader := &Loader{}
+			_, err := loader.GetHttpConfig()
+			assert.True(t, errors.Is(err, ErrConfigInvalid))
+		})
+	}
+}
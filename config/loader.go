@@ -1,6 +1,9 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
 	"strings"
 
 	"github.com/zerpto/ponodo/config/contracts"
@@ -19,7 +22,11 @@ func (c *Loader) loadFromEnvironmentVariable() error {
 	viper.SetConfigFile(".env")
 	err := viper.ReadInConfig()
 	if err != nil {
-		return err
+		var notFound viper.ConfigFileNotFoundError
+		if errors.Is(err, fs.ErrNotExist) || errors.As(err, &notFound) {
+			return fmt.Errorf("%w: %w", ErrConfigMissing, err)
+		}
+		return fmt.Errorf("%w: %w", ErrConfigInvalid, err)
 	}
 	viper.AutomaticEnv()
 	viper.SetEnvPrefix("")
@@ -43,7 +50,8 @@ func (c *Loader) loadFromEnvironmentVariable() error {
 
 // NewLoader creates a new configuration loader instance.
 // It initializes the loader and loads configuration from environment variables.
-// Returns an error if the configuration cannot be loaded.
+// Returns an error wrapping ErrConfigMissing when the .env file does not exist
+// and ErrConfigInvalid when it cannot be parsed.
 func NewLoader() (*Loader, error) {
 	loader := Loader{}
 	err := loader.loadFromEnvironmentVariable()
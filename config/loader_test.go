@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"testing"
 )
@@ -56,3 +57,23 @@ func TestNewLoader(t *testing.T) {
 		t.Error("NewLoader returned nil loader")
 	}
 }
+
+func TestNewLoader_ConfigMissing(t *testing.T) {
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer os.Chdir(oldDir)
+
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	loader, err := NewLoader()
+	if loader != nil {
+		t.Error("NewLoader returned a loader without a .env file")
+	}
+	if !errors.Is(err, ErrConfigMissing) {
+		t.Errorf("NewLoader error = %v, want ErrConfigMissing", err)
+	}
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Log formats supported by LOG_FORMAT.
+const (
+	// LogFormatJSON writes one JSON object per entry.
+	LogFormatJSON = "json"
+
+	// LogFormatConsole writes colorized, human readable entries.
+	LogFormatConsole = "console"
+)
+
+// Log outputs supported by LOG_OUTPUT.
+const (
+	LogOutputStderr = "stderr"
+	LogOutputFile   = "file"
+	LogOutputBoth   = "both"
+)
+
+// DefaultLogFile is the file written by the file output when LOG_FILE is
+// not set.
+const DefaultLogFile = "app.log"
+
+// LogConfig holds the settings of the application logger.
+type LogConfig struct {
+	// Level is a zerolog level name such as debug, info or warn.
+	Level  string
+	Format string
+	Output string
+	File   string
+
+	// Caller adds the file and line of the logging call to every entry.
+	Caller bool
+
+	// SampleRate keeps one in SampleRate debug and info entries. Entries
+	// of the warn level and above are never sampled. Zero and one keep
+	// every entry.
+	SampleRate uint32
+
+	// MaxSize is the size in megabytes after which File is rotated. Zero
+	// disables size based rotation.
+	MaxSize int
+
+	// RotateInterval rotates File once it has been open this long. Zero
+	// disables time based rotation.
+	RotateInterval time.Duration
+
+	// MaxBackups is the number of rotated files kept and MaxAge the age
+	// after which they are removed. Zero keeps them all.
+	MaxBackups int
+	MaxAge     time.Duration
+
+	// Compress gzips rotated files.
+	Compress bool
+}
+
+// WritesStderr reports whether entries are written to standard error.
+func (c *LogConfig) WritesStderr() bool {
+	return c.Output == LogOutputStderr || c.Output == LogOutputBoth
+}
+
+// WritesFile reports whether entries are written to File.
+func (c *LogConfig) WritesFile() bool {
+	return c.Output == LogOutputFile || c.Output == LogOutputBoth
+}
+
+// GetLogConfig builds the logger configuration from LOG_LEVEL, LOG_FORMAT,
+// LOG_OUTPUT, LOG_FILE, LOG_CALLER and LOG_SAMPLE_RATE, and the rotation of
+// the log file from LOG_MAX_SIZE, LOG_ROTATE_INTERVAL, LOG_MAX_BACKUPS,
+// LOG_MAX_AGE and LOG_COMPRESS. The level defaults to debug when DEBUG is
+// enabled and to info otherwise, and entries are written as JSON to
+// standard error unless configured otherwise. An error wrapping
+// ErrConfigInvalid is returned for unknown or negative values.
+func (c *Loader) GetLogConfig() (*LogConfig, error) {
+	logConfig := &LogConfig{
+		Level:      strings.ToLower(viper.GetString("log_level")),
+		Format:     strings.ToLower(viper.GetString("log_format")),
+		Output:     strings.ToLower(viper.GetString("log_output")),
+		File:       viper.GetString("log_file"),
+		Caller:     viper.GetBool("log_caller"),
+		SampleRate: viper.GetUint32("log_sample_rate"),
+		MaxSize:    viper.GetInt("log_max_size"),
+		MaxBackups: viper.GetInt("log_max_backups"),
+		Compress:   viper.GetBool("log_compress"),
+	}
+	if logConfig.Level == "" {
+		logConfig.Level = "info"
+		if c.Config != nil && c.Config.GetDebug() {
+			logConfig.Level = "debug"
+		}
+	}
+	if logConfig.Format == "" {
+		logConfig.Format = LogFormatJSON
+	}
+	if logConfig.Output == "" {
+		logConfig.Output = LogOutputStderr
+	}
+	if logConfig.File == "" {
+		logConfig.File = DefaultLogFile
+	}
+
+	switch logConfig.Level {
+	case "trace", "debug", "info", "warn", "error", "fatal", "panic", "disabled":
+	default:
+		return nil, fmt.Errorf("%w: LOG_LEVEL: unknown level %q", ErrConfigInvalid, logConfig.Level)
+	}
+	switch logConfig.Format {
+	case LogFormatJSON, LogFormatConsole:
+	default:
+		return nil, fmt.Errorf("%w: LOG_FORMAT: unknown format %q", ErrConfigInvalid, logConfig.Format)
+	}
+	switch logConfig.Output {
+	case LogOutputStderr, LogOutputFile, LogOutputBoth:
+	default:
+		return nil, fmt.Errorf("%w: LOG_OUTPUT: unknown output %q", ErrConfigInvalid, logConfig.Output)
+	}
+	if logConfig.MaxSize < 0 {
+		return nil, fmt.Errorf("%w: LOG_MAX_SIZE: must not be negative", ErrConfigInvalid)
+	}
+	if logConfig.MaxBackups < 0 {
+		return nil, fmt.Errorf("%w: LOG_MAX_BACKUPS: must not be negative", ErrConfigInvalid)
+	}
+
+	var err error
+	if logConfig.RotateInterval, err = parseDuration("log_rotate_interval"); err != nil {
+		return nil, err
+	}
+	if logConfig.MaxAge, err = parseDuration("log_max_age"); err != nil {
+		return nil, err
+	}
+	if logConfig.RotateInterval < 0 || logConfig.MaxAge < 0 {
+		return nil, fmt.Errorf("%w: LOG_ROTATE_INTERVAL and LOG_MAX_AGE must not be negative", ErrConfigInvalid)
+	}
+	return logConfig, nil
+}
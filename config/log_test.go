@@ -0,0 +1,108 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zerpto/ponodo/config/contracts/mocks"
+)
+
+func TestLoader_GetLogConfig_Defaults(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	logConfig, err := (&Loader{}).GetLogConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, "info", logConfig.Level)
+	assert.Equal(t, LogFormatJSON, logConfig.Format)
+	assert.Equal(t, LogOutputStderr, logConfig.Output)
+	assert.Equal(t, DefaultLogFile, logConfig.File)
+	assert.True(t, logConfig.WritesStderr())
+	assert.False(t, logConfig.WritesFile())
+	assert.False(t, logConfig.Caller)
+	assert.Zero(t, logConfig.SampleRate)
+	assert.Zero(t, logConfig.MaxSize)
+	assert.Zero(t, logConfig.RotateInterval)
+	assert.Zero(t, logConfig.MaxBackups)
+	assert.Zero(t, logConfig.MaxAge)
+	assert.False(t, logConfig.Compress)
+}
+
+func TestLoader_GetLogConfig_DebugLevel(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConfig := mocks.NewMockConfigContract(ctrl)
+	mockConfig.EXPECT().GetDebug().Return(true)
+
+	logConfig, err := (&Loader{Config: mockConfig}).GetLogConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "debug", logConfig.Level)
+}
+
+func TestLoader_GetLogConfig(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("log_level", "WARN")
+	viper.Set("log_format", "console")
+	viper.Set("log_output", "both")
+	viper.Set("log_file", "/var/log/app.log")
+	viper.Set("log_caller", "true")
+	viper.Set("log_sample_rate", "10")
+	viper.Set("log_max_size", "100")
+	viper.Set("log_rotate_interval", "24h")
+	viper.Set("log_max_backups", "7")
+	viper.Set("log_max_age", "720h")
+	viper.Set("log_compress", "true")
+
+	logConfig, err := (&Loader{}).GetLogConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, "warn", logConfig.Level)
+	assert.Equal(t, LogFormatConsole, logConfig.Format)
+	assert.True(t, logConfig.WritesStderr())
+	assert.True(t, logConfig.WritesFile())
+	assert.Equal(t, "/var/log/app.log", logConfig.File)
+	assert.True(t, logConfig.Caller)
+	assert.Equal(t, uint32(10), logConfig.SampleRate)
+	assert.Equal(t, 100, logConfig.MaxSize)
+	assert.Equal(t, 24*time.Hour, logConfig.RotateInterval)
+	assert.Equal(t, 7, logConfig.MaxBackups)
+	assert.Equal(t, 720*time.Hour, logConfig.MaxAge)
+	assert.True(t, logConfig.Compress)
+}
+
+func TestLoader_GetLogConfig_Invalid(t *testing.T) {
+	tests := map[string]map[string]string{
+		"unknown level":  {"log_level": "verbose"},
+		"unknown format": {"log_format": "xml"},
+		"unknown output": {"log_output": "syslog"},
+		"negative size":  {"log_max_size": "-1"},
+		"negative count": {"log_max_backups": "-1"},
+		"invalid age":    {"log_max_age": "month"},
+		"negative age":   {"log_max_age": "-1h"},
+	}
+	for name, values := range tests {
+		t.Run(name, func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+			for key, value := range values {
+				viper.Set(key, value)
+			}
+
+			_, err := (&Loader{}).GetLogConfig()
+			assert.True(t, errors.Is(err, ErrConfigInvalid))
+		})
+	}
+}
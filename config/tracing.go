@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Trace exporters supported by TRACING_EXPORTER.
+const (
+	// TracingExporterNone disables tracing.
+	TracingExporterNone = "none"
+
+	// TracingExporterOTLP sends spans to an OTLP/HTTP collector.
+	TracingExporterOTLP = "otlp"
+
+	// TracingExporterStdout writes spans as JSON to standard output.
+	TracingExporterStdout = "stdout"
+
+	// TracingExporterFile appends spans as JSON to a file, which is useful
+	// for inspecting traces offline and in tests.
+	TracingExporterFile = "file"
+)
+
+// DefaultTracingFile is the file written by the file exporter when
+// TRACING_FILE is not set.
+const DefaultTracingFile = "traces.json"
+
+// TracingConfig holds the OpenTelemetry tracing settings.
+type TracingConfig struct {
+	Exporter    string
+	ServiceName string
+	SampleRatio float64
+
+	// Endpoint is the host:port of the OTLP collector. When empty the
+	// standard OTEL_EXPORTER_OTLP_* environment variables apply.
+	Endpoint string
+	Insecure bool
+
+	File string
+}
+
+// Enabled reports whether spans are exported.
+func (c *TracingConfig) Enabled() bool {
+	return c.Exporter != TracingExporterNone
+}
+
+// GetTracingConfig builds the tracing configuration from TRACING_EXPORTER,
+// TRACING_SERVICE_NAME, TRACING_SAMPLE_RATIO, TRACING_ENDPOINT,
+// TRACING_INSECURE and TRACING_FILE. Tracing is disabled unless an exporter
+// is set, the service name defaults to APP and every trace is sampled
+// unless a ratio between 0 and 1 is set. An error wrapping ErrConfigInvalid
+// is returned for an unknown exporter or an invalid ratio.
+func (c *Loader) GetTracingConfig() (*TracingConfig, error) {
+	tracingConfig := &TracingConfig{
+		Exporter:    strings.ToLower(viper.GetString("tracing_exporter")),
+		ServiceName: viper.GetString("tracing_service_name"),
+		SampleRatio: 1,
+		Endpoint:    viper.GetString("tracing_endpoint"),
+		Insecure:    viper.GetBool("tracing_insecure"),
+		File:        viper.GetString("tracing_file"),
+	}
+	if tracingConfig.Exporter == "" {
+		tracingConfig.Exporter = TracingExporterNone
+	}
+	if tracingConfig.ServiceName == "" && c.Config != nil {
+		tracingConfig.ServiceName = c.Config.GetApp()
+	}
+	if tracingConfig.File == "" {
+		tracingConfig.File = DefaultTracingFile
+	}
+
+	switch tracingConfig.Exporter {
+	case TracingExporterNone, TracingExporterOTLP, TracingExporterStdout, TracingExporterFile:
+	default:
+		return nil, fmt.Errorf("%w: TRACING_EXPORTER: unknown exporter %q", ErrConfigInvalid, tracingConfig.Exporter)
+	}
+
+	if raw := viper.GetString("tracing_sample_ratio"); raw != "" {
+		ratio, err := strconv.ParseFloat(raw, 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			return nil, fmt.Errorf("%w: TRACING_SAMPLE_RATIO: expected a number between 0 and 1, got %q", ErrConfigInvalid, raw)
+		}
+		tracingConfig.SampleRatio = ratio
+	}
+	return tracingConfig, nil
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zerpto/ponodo/config/contracts/mocks"
+)
+
+func TestLoader_GetTracingConfig_Defaults(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConfig := mocks.NewMockConfigContract(ctrl)
+	mockConfig.EXPECT().GetApp().Return("orders")
+
+	loader := &Loader{Config: mockConfig}
+	tracingConfig, err := loader.GetTracingConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, TracingExporterNone, tracingConfig.Exporter)
+	assert.False(t, tracingConfig.Enabled())
+	assert.Equal(t, "orders", tracingConfig.ServiceName)
+	assert.Equal(t, float64(1), tracingConfig.SampleRatio)
+	assert.Equal(t, DefaultTracingFile, tracingConfig.File)
+}
+
+func TestLoader_GetTracingConfig(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("tracing_exporter", "OTLP")
+	viper.Set("tracing_service_name", "orders-api")
+	viper.Set("tracing_sample_ratio", "0.25")
+	viper.Set("tracing_endpoint", "collector:4318")
+	viper.Set("tracing_insecure", "true")
+
+	loader := &Loader{}
+	tracingConfig, err := loader.GetTracingConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, TracingExporterOTLP, tracingConfig.Exporter)
+	assert.True(t, tracingConfig.Enabled())
+	assert.Equal(t, "orders-api", tracingConfig.ServiceName)
+	assert.Equal(t, 0.25, tracingConfig.SampleRatio)
+	assert.Equal(t, "collector:4318", tracingConfig.Endpoint)
+	assert.True(t, tracingConfig.Insecure)
+}
+
+func TestLoader_GetTracingConfig_Invalid(t *testing.T) {
+	tests := map[string]map[string]string{
+		"unknown exporter":   {"tracing_exporter": "jaeger"},
+		"ratio out of range": {"tracing_sample_ratio": "1.5"},
+		"ratio not a number": {"tracing_sample_ratio": "half"},
+	}
+	for name, values := range tests {
+		t.Run(name, func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+			for key, value := range values {
+				viper.Set(key, value)
+			}
+
+			_, err := (&Loader{}).GetTracingConfig()
+			assert.True(t, errors.Is(err, ErrConfigInvalid))
+		})
+	}
+}
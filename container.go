@@ -0,0 +1,50 @@
+package ponodo
+
+import (
+	"github.com/zerpto/ponodo/container"
+	"github.com/zerpto/ponodo/contracts"
+)
+
+// Bind registers a transient binding for T in the application container.
+// The factory is invoked every time T is resolved.
+func Bind[T any](app contracts.AppContract, factory container.Factory[T]) {
+	container.Bind(app.GetContainer(), factory)
+}
+
+// Singleton registers a singleton binding for T in the application container.
+// The factory is invoked once and the instance is shared for the lifetime
+// of the application.
+func Singleton[T any](app contracts.AppContract, factory container.Factory[T]) {
+	container.Singleton(app.GetContainer(), factory)
+}
+
+// Scoped registers a scoped binding for T in the application container.
+// The factory is invoked once per scope created with NewScope.
+func Scoped[T any](app contracts.AppContract, factory container.Factory[T]) {
+	container.Scoped(app.GetContainer(), factory)
+}
+
+// Instance registers an already constructed value as the singleton
+// binding for T in the application container.
+func Instance[T any](app contracts.AppContract, instance T) {
+	container.Instance(app.GetContainer(), instance)
+}
+
+// Make resolves T from the application container. It returns an error
+// when T has no binding, when its dependencies form a cycle, or when T is
+// scoped and must be resolved with MakeScoped instead.
+func Make[T any](app contracts.AppContract) (T, error) {
+	return container.Make[T](app.GetContainer())
+}
+
+// NewScope creates a new resolution scope on the application container.
+// Scoped bindings resolved through the same scope share one instance.
+func NewScope(app contracts.AppContract) *container.Scope {
+	return app.GetContainer().NewScope()
+}
+
+// MakeScoped resolves T within the given scope. Singleton and transient
+// bindings behave as they do with Make.
+func MakeScoped[T any](scope *container.Scope) (T, error) {
+	return container.Make[T](scope)
+}
@@ -0,0 +1,328 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrBindingNotFound is returned when a type is resolved that has no
+	// binding registered in the container.
+	ErrBindingNotFound = errors.New("container: binding not found")
+
+	// ErrCircularDependency is returned when resolving a type requires
+	// resolving itself, directly or through other bindings.
+	ErrCircularDependency = errors.New("container: circular dependency")
+
+	// ErrScopeRequired is returned when a scoped binding is resolved
+	// directly from the container instead of from a Scope.
+	ErrScopeRequired = errors.New("container: scoped binding resolved outside of a scope")
+)
+
+// Lifetime describes how long an instance produced by a binding lives.
+type Lifetime int
+
+const (
+	// LifetimeTransient bindings produce a new instance on every resolution.
+	LifetimeTransient Lifetime = iota
+	// LifetimeSingleton bindings produce one instance for the whole container.
+	LifetimeSingleton
+	// LifetimeScoped bindings produce one instance per Scope.
+	LifetimeScoped
+)
+
+// String returns the human readable name of the lifetime.
+func (l Lifetime) String() string {
+	switch l {
+	case LifetimeSingleton:
+		return "singleton"
+	case LifetimeScoped:
+		return "scoped"
+	default:
+		return "transient"
+	}
+}
+
+// Factory builds an instance of T. The provided Resolver must be used to
+// resolve dependencies so that circular dependencies can be detected.
+type Factory[T any] func(r Resolver) (T, error)
+
+// Resolver is implemented by Container and Scope, and by the resolver handed
+// to factories. It is the argument accepted by Make.
+type Resolver interface {
+	resolve(t reflect.Type) (any, error)
+}
+
+type binding struct {
+	lifetime Lifetime
+	factory  func(r Resolver) (any, error)
+
+	mu       sync.Mutex
+	resolved bool
+	instance any
+}
+
+// Container holds type bindings and resolves them on demand. It is safe for
+// concurrent use.
+type Container struct {
+	mu       sync.RWMutex
+	bindings map[reflect.Type]*binding
+
+	// singletonMu is held while singleton factories run, so that each of
+	// them runs once even when the singleton is resolved concurrently. It is
+	// taken by the outermost singleton of a resolution only; singletons
+	// resolved by its factory run under the same lock. A single lock rather
+	// than one per binding keeps goroutines resolving the singletons of a
+	// cycle from waiting on each other forever, so the cycle is reported.
+	singletonMu sync.Mutex
+}
+
+// Scope resolves scoped bindings once per scope while delegating singleton
+// and transient bindings to its parent container. A scope is typically
+// created per HTTP request or per CLI command run.
+type Scope struct {
+	container *Container
+
+	mu        sync.Mutex
+	instances map[reflect.Type]any
+}
+
+// resolution carries the chain of types being resolved so that a factory
+// resolving its own dependencies can be checked for cycles.
+type resolution struct {
+	container *Container
+	scope     *Scope
+	chain     []reflect.Type
+
+	// constructing is set when the chain holds singletonMu.
+	constructing bool
+}
+
+// New creates an empty container.
+func New() *Container {
+	return &Container{
+		bindings: make(map[reflect.Type]*binding),
+	}
+}
+
+// NewScope creates a new scope backed by the container.
+func (c *Container) NewScope() *Scope {
+	return &Scope{
+		container: c,
+		instances: make(map[reflect.Type]any),
+	}
+}
+
+// Has reports whether a binding exists for the given type.
+func (c *Container) Has(t reflect.Type) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.bindings[t]
+	return ok
+}
+
+// Lifetime returns the lifetime of the binding registered for the given type.
+func (c *Container) Lifetime(t reflect.Type) (Lifetime, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	b, ok := c.bindings[t]
+	if !ok {
+		return 0, false
+	}
+	return b.lifetime, true
+}
+
+func (c *Container) bind(t reflect.Type, lifetime Lifetime, factory func(r Resolver) (any, error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.bindings == nil {
+		c.bindings = make(map[reflect.Type]*binding)
+	}
+	c.bindings[t] = &binding{
+		lifetime: lifetime,
+		factory:  factory,
+	}
+}
+
+func (c *Container) lookup(t reflect.Type) (*binding, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	b, ok := c.bindings[t]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrBindingNotFound, t)
+	}
+	return b, nil
+}
+
+func (c *Container) resolve(t reflect.Type) (any, error) {
+	return c.resolveIn(nil, t, nil, false)
+}
+
+func (c *Container) resolveIn(scope *Scope, t reflect.Type, chain []reflect.Type, constructing bool) (any, error) {
+	for _, seen := range chain {
+		if seen == t {
+			return nil, fmt.Errorf("%w: %s", ErrCircularDependency, formatChain(append(chain, t)))
+		}
+	}
+
+	b, err := c.lookup(t)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &resolution{
+		container:    c,
+		scope:        scope,
+		chain:        append(chain[:len(chain):len(chain)], t),
+		constructing: constructing,
+	}
+
+	switch b.lifetime {
+	case LifetimeSingleton:
+		// Singletons outlive every scope, so they must not capture scoped
+		// instances through their dependencies.
+		r.scope = nil
+
+		if instance, ok := b.load(); ok {
+			return instance, nil
+		}
+
+		if !r.constructing {
+			c.singletonMu.Lock()
+			defer c.singletonMu.Unlock()
+			r.constructing = true
+
+			// Another goroutine may have built the instance while this one
+			// waited for the lock.
+			if instance, ok := b.load(); ok {
+				return instance, nil
+			}
+		}
+
+		instance, err := b.factory(r)
+		if err != nil {
+			return nil, err
+		}
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.instance = instance
+		b.resolved = true
+		return instance, nil
+	case LifetimeScoped:
+		if scope == nil {
+			return nil, fmt.Errorf("%w: %s", ErrScopeRequired, t)
+		}
+
+		scope.mu.Lock()
+		if instance, ok := scope.instances[t]; ok {
+			scope.mu.Unlock()
+			return instance, nil
+		}
+		scope.mu.Unlock()
+
+		instance, err := b.factory(r)
+		if err != nil {
+			return nil, err
+		}
+
+		scope.mu.Lock()
+		defer scope.mu.Unlock()
+		if existing, ok := scope.instances[t]; ok {
+			return existing, nil
+		}
+		scope.instances[t] = instance
+		return instance, nil
+	default:
+		return b.factory(r)
+	}
+}
+
+// load returns the instance of a resolved singleton binding.
+func (b *binding) load() (any, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.instance, b.resolved
+}
+
+func (s *Scope) resolve(t reflect.Type) (any, error) {
+	return s.container.resolveIn(s, t, nil, false)
+}
+
+func (r *resolution) resolve(t reflect.Type) (any, error) {
+	return r.container.resolveIn(r.scope, t, r.chain, r.constructing)
+}
+
+func formatChain(chain []reflect.Type) string {
+	names := make([]string, len(chain))
+	for i, t := range chain {
+		names[i] = t.String()
+	}
+	return strings.Join(names, " -> ")
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func register[T any](c *Container, lifetime Lifetime, factory Factory[T]) {
+	c.bind(typeOf[T](), lifetime, func(r Resolver) (any, error) {
+		return factory(r)
+	})
+}
+
+// Bind registers a transient binding for T. The factory is invoked on
+// every resolution.
+func Bind[T any](c *Container, factory Factory[T]) {
+	register(c, LifetimeTransient, factory)
+}
+
+// Singleton registers a singleton binding for T. The factory is invoked
+// at most once and its result is shared by every resolution. Concurrent
+// resolutions wait for the factory to return, so it must resolve its
+// dependencies through the given Resolver on the calling goroutine.
+func Singleton[T any](c *Container, factory Factory[T]) {
+	register(c, LifetimeSingleton, factory)
+}
+
+// Scoped registers a scoped binding for T. The factory is invoked once per
+// Scope; resolving T directly from the container returns ErrScopeRequired.
+func Scoped[T any](c *Container, factory Factory[T]) {
+	register(c, LifetimeScoped, factory)
+}
+
+// Instance registers an already built value as a singleton binding for T.
+func Instance[T any](c *Container, instance T) {
+	Singleton(c, func(Resolver) (T, error) {
+		return instance, nil
+	})
+}
+
+// Has reports whether a binding for T exists in the container.
+func Has[T any](c *Container) bool {
+	return c.Has(typeOf[T]())
+}
+
+// Make resolves T from the given resolver. It returns ErrBindingNotFound
+// when T is not bound and ErrCircularDependency when T depends on itself.
+func Make[T any](r Resolver) (T, error) {
+	var zero T
+
+	t := typeOf[T]()
+	instance, err := r.resolve(t)
+	if err != nil {
+		return zero, err
+	}
+	if instance == nil {
+		return zero, nil
+	}
+
+	typed, ok := instance.(T)
+	if !ok {
+		return zero, fmt.Errorf("container: binding for %s produced %T", t, instance)
+	}
+	return typed, nil
+}
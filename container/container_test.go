@@ -0,0 +1,254 @@
+package container
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testService struct {
+	ID int
+}
+
+type testDependent struct {
+	Service *testService
+}
+
+type testCycleA struct{}
+
+type testCycleB struct{}
+
+type testGreeter interface {
+	Greet() string
+}
+
+type testEnglishGreeter struct{}
+
+func (testEnglishGreeter) Greet() string { return "hello" }
+
+func TestLifetime_String(t *testing.T) {
+	assert.Equal(t, "transient", LifetimeTransient.String())
+	assert.Equal(t, "singleton", LifetimeSingleton.String())
+	assert.Equal(t, "scoped", LifetimeScoped.String())
+}
+
+func TestMake_BindingNotFound(t *testing.T) {
+	c := New()
+
+	_, err := Make[*testService](c)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrBindingNotFound))
+}
+
+func TestBind_Transient(t *testing.T) {
+	c := New()
+	counter := 0
+	Bind(c, func(Resolver) (*testService, error) {
+		counter++
+		return &testService{ID: counter}, nil
+	})
+
+	first, err := Make[*testService](c)
+	require.NoError(t, err)
+	second, err := Make[*testService](c)
+	require.NoError(t, err)
+
+	assert.NotSame(t, first, second)
+	assert.Equal(t, 2, counter)
+}
+
+func TestSingleton(t *testing.T) {
+	c := New()
+	Singleton(c, func(Resolver) (*testService, error) {
+		return &testService{}, nil
+	})
+
+	var wg sync.WaitGroup
+	results := make([]*testService, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			service, err := Make[*testService](c)
+			assert.NoError(t, err)
+			results[i] = service
+		}(i)
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		assert.Same(t, results[0], result)
+	}
+}
+
+func TestSingleton_FactoryRunsOnce(t *testing.T) {
+	c := New()
+	var calls atomic.Int32
+	Singleton(c, func(Resolver) (*testService, error) {
+		calls.Add(1)
+		time.Sleep(time.Millisecond)
+		return &testService{}, nil
+	})
+	Singleton(c, func(r Resolver) (*testDependent, error) {
+		service, err := Make[*testService](r)
+		return &testDependent{Service: service}, err
+	})
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			var err error
+			if i%2 == 0 {
+				_, err = Make[*testService](c)
+			} else {
+				_, err = Make[*testDependent](c)
+			}
+			assert.NoError(t, err)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestSingleton_ConcurrentCircularDependency(t *testing.T) {
+	c := New()
+	Singleton(c, func(r Resolver) (*testCycleA, error) {
+		time.Sleep(time.Millisecond)
+		_, err := Make[*testCycleB](r)
+		return &testCycleA{}, err
+	})
+	Singleton(c, func(r Resolver) (*testCycleB, error) {
+		time.Sleep(time.Millisecond)
+		_, err := Make[*testCycleA](r)
+		return &testCycleB{}, err
+	})
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, errs[0] = Make[*testCycleA](c)
+	}()
+	go func() {
+		defer wg.Done()
+		_, errs[1] = Make[*testCycleB](c)
+	}()
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.ErrorIs(t, err, ErrCircularDependency)
+	}
+}
+
+func TestScoped(t *testing.T) {
+	c := New()
+	Scoped(c, func(Resolver) (*testService, error) {
+		return &testService{}, nil
+	})
+
+	_, err := Make[*testService](c)
+	assert.True(t, errors.Is(err, ErrScopeRequired))
+
+	scopeA := c.NewScope()
+	scopeB := c.NewScope()
+
+	a1 := mustMake[*testService](t, scopeA)
+	a2 := mustMake[*testService](t, scopeA)
+	b1 := mustMake[*testService](t, scopeB)
+
+	assert.Same(t, a1, a2)
+	assert.NotSame(t, a1, b1)
+}
+
+func TestSingleton_CannotCaptureScoped(t *testing.T) {
+	c := New()
+	Scoped(c, func(Resolver) (*testService, error) {
+		return &testService{}, nil
+	})
+	Singleton(c, func(r Resolver) (*testDependent, error) {
+		service, err := Make[*testService](r)
+		if err != nil {
+			return nil, err
+		}
+		return &testDependent{Service: service}, nil
+	})
+
+	_, err := Make[*testDependent](c.NewScope())
+	assert.True(t, errors.Is(err, ErrScopeRequired))
+}
+
+func TestMake_ResolvesDependencies(t *testing.T) {
+	c := New()
+	Instance(c, &testService{ID: 42})
+	Bind(c, func(r Resolver) (*testDependent, error) {
+		service, err := Make[*testService](r)
+		if err != nil {
+			return nil, err
+		}
+		return &testDependent{Service: service}, nil
+	})
+
+	dependent, err := Make[*testDependent](c)
+	require.NoError(t, err)
+	assert.Equal(t, 42, dependent.Service.ID)
+}
+
+func TestMake_CircularDependency(t *testing.T) {
+	c := New()
+	Bind(c, func(r Resolver) (*testCycleA, error) {
+		_, err := Make[*testCycleB](r)
+		return &testCycleA{}, err
+	})
+	Bind(c, func(r Resolver) (*testCycleB, error) {
+		_, err := Make[*testCycleA](r)
+		return &testCycleB{}, err
+	})
+
+	_, err := Make[*testCycleA](c)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrCircularDependency))
+	assert.Contains(t, err.Error(), "*container.testCycleA -> *container.testCycleB -> *container.testCycleA")
+}
+
+func TestMake_Interface(t *testing.T) {
+	c := New()
+	Singleton(c, func(Resolver) (testGreeter, error) {
+		return testEnglishGreeter{}, nil
+	})
+
+	greeter, err := Make[testGreeter](c)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", greeter.Greet())
+	assert.True(t, Has[testGreeter](c))
+	assert.False(t, Has[*testService](c))
+}
+
+func TestMake_FactoryError(t *testing.T) {
+	c := New()
+	expected := errors.New("boom")
+	Singleton(c, func(Resolver) (*testService, error) {
+		return nil, expected
+	})
+
+	_, err := Make[*testService](c)
+	assert.Equal(t, expected, err)
+}
+
+func mustMake[T any](t *testing.T, r Resolver) T {
+	t.Helper()
+	instance, err := Make[T](r)
+	require.NoError(t, err)
+	return instance
+}
@@ -0,0 +1,83 @@
+package ponodo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zerpto/ponodo/container"
+)
+
+type testCache struct {
+	Name string
+}
+
+type testRepository struct {
+	Cache *testCache
+}
+
+func TestApp_GetContainer(t *testing.T) {
+	app := &App{}
+	c := app.GetContainer()
+	require.NotNil(t, c)
+	assert.Same(t, c, app.GetContainer())
+}
+
+func TestMake(t *testing.T) {
+	app := NewApp()
+
+	Singleton(app, func(container.Resolver) (*testCache, error) {
+		return &testCache{Name: "redis"}, nil
+	})
+	Bind(app, func(r container.Resolver) (*testRepository, error) {
+		cache, err := container.Make[*testCache](r)
+		if err != nil {
+			return nil, err
+		}
+		return &testRepository{Cache: cache}, nil
+	})
+
+	repository, err := Make[*testRepository](app)
+	require.NoError(t, err)
+	assert.Equal(t, "redis", repository.Cache.Name)
+
+	cache, err := Make[*testCache](app)
+	require.NoError(t, err)
+	assert.Same(t, cache, repository.Cache)
+}
+
+func TestMake_Missing(t *testing.T) {
+	app := NewApp()
+
+	_, err := Make[*testCache](app)
+	assert.True(t, errors.Is(err, container.ErrBindingNotFound))
+}
+
+func TestMakeScoped(t *testing.T) {
+	app := NewApp()
+	Scoped(app, func(container.Resolver) (*testCache, error) {
+		return &testCache{}, nil
+	})
+
+	_, err := Make[*testCache](app)
+	assert.True(t, errors.Is(err, container.ErrScopeRequired))
+
+	scope := NewScope(app)
+	first, err := MakeScoped[*testCache](scope)
+	require.NoError(t, err)
+	second, err := MakeScoped[*testCache](scope)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+}
+
+func TestInstance(t *testing.T) {
+	app := NewApp()
+	cache := &testCache{Name: "memory"}
+	Instance(app, cache)
+
+	resolved, err := Make[*testCache](app)
+	require.NoError(t, err)
+	assert.Same(t, cache, resolved)
+}
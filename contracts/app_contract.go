@@ -1,10 +1,16 @@
 package contracts
 
 import (
+	"database/sql"
+
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
 	clicontracts "github.com/zerpto/ponodo/cli/contracts"
 	"github.com/zerpto/ponodo/config"
+	"github.com/zerpto/ponodo/container"
+	"github.com/zerpto/ponodo/migration"
 	"gorm.io/gorm"
 )
 
@@ -14,16 +20,35 @@ import (
 //
 //go:generate mockgen -source=$GOFILE -destination=./mocks/mock_app_contract.go -package=mocks
 type AppContract interface {
-	SetupBaseDependencies()
+	SetupBaseDependencies() error
 	Run()
+	Shutdown() error
 
 	AddCommand(func(app AppContract) clicontracts.CommandContract)
+	RegisterProvider(ServiceProviderContract)
+	RemoveProvider(name string)
+	GetProviders() []ServiceProviderContract
+	AddMigrations(migrations ...migration.Migration)
+	GetMigrations() []migration.Migration
+	AddSeeders(seeders ...SeederContract)
+	GetSeeders() []SeederContract
+	AddHealthChecks(checks ...HealthCheckContract)
+	GetHealthChecks() []HealthCheckContract
 
 	SetConfigLoader(*config.Loader)
 	GetConfigLoader() *config.Loader
 	SetGin(*gin.Engine)
 	GetGin() *gin.Engine
+	SetDb(*gorm.DB)
 	GetDb() *gorm.DB
+	SetDbConnection(name string, db *gorm.DB)
+	GetDbConnection(name string) (*gorm.DB, error)
+	GetDbConnections() map[string]*gorm.DB
+	GetDbStats() sql.DBStats
+	SetLogger(*zerolog.Logger)
+	GetLogger() *zerolog.Logger
 	SetValidator(*validator.Validate)
 	GetValidator() *validator.Validate
+	GetContainer() *container.Container
+	GetMetricsRegistry() *prometheus.Registry
 }
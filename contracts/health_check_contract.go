@@ -0,0 +1,15 @@
+package contracts
+
+import "context"
+
+// HealthCheckContract defines the interface for health checks. Checks are
+// registered on the application, typically by service providers, and run
+// by the /healthz and /readyz endpoints of the http command. Check returns
+// an error when the dependency it covers is unavailable and should give up
+// when the context is done.
+//
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_health_check_contract.go -package=mocks
+type HealthCheckContract interface {
+	Name() string
+	Check(ctx context.Context) error
+}
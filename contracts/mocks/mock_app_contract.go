@@ -10,13 +10,18 @@
 package mocks
 
 import (
+	sql "database/sql"
 	reflect "reflect"
 
 	gin "github.com/gin-gonic/gin"
 	validator "github.com/go-playground/validator/v10"
+	prometheus "github.com/prometheus/client_golang/prometheus"
+	zerolog "github.com/rs/zerolog"
 	contracts "github.com/zerpto/ponodo/cli/contracts"
 	config "github.com/zerpto/ponodo/config"
+	container "github.com/zerpto/ponodo/container"
 	contracts0 "github.com/zerpto/ponodo/contracts"
+	migration "github.com/zerpto/ponodo/migration"
 	gomock "go.uber.org/mock/gomock"
 	gorm "gorm.io/gorm"
 )
@@ -57,6 +62,54 @@ func (mr *MockAppContractMockRecorder) AddCommand(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCommand", reflect.TypeOf((*MockAppContract)(nil).AddCommand), arg0)
 }
 
+// AddHealthChecks mocks base method.
+func (m *MockAppContract) AddHealthChecks(checks ...contracts0.HealthCheckContract) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range checks {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "AddHealthChecks", varargs...)
+}
+
+// AddHealthChecks indicates an expected call of AddHealthChecks.
+func (mr *MockAppContractMockRecorder) AddHealthChecks(checks ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHealthChecks", reflect.TypeOf((*MockAppContract)(nil).AddHealthChecks), checks...)
+}
+
+// AddMigrations mocks base method.
+func (m *MockAppContract) AddMigrations(migrations ...migration.Migration) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range migrations {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "AddMigrations", varargs...)
+}
+
+// AddMigrations indicates an expected call of AddMigrations.
+func (mr *MockAppContractMockRecorder) AddMigrations(migrations ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMigrations", reflect.TypeOf((*MockAppContract)(nil).AddMigrations), migrations...)
+}
+
+// AddSeeders mocks base method.
+func (m *MockAppContract) AddSeeders(seeders ...contracts0.SeederContract) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range seeders {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "AddSeeders", varargs...)
+}
+
+// AddSeeders indicates an expected call of AddSeeders.
+func (mr *MockAppContractMockRecorder) AddSeeders(seeders ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSeeders", reflect.TypeOf((*MockAppContract)(nil).AddSeeders), seeders...)
+}
+
 // GetConfigLoader mocks base method.
 func (m *MockAppContract) GetConfigLoader() *config.Loader {
 	m.ctrl.T.Helper()
@@ -71,6 +124,20 @@ func (mr *MockAppContractMockRecorder) GetConfigLoader() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigLoader", reflect.TypeOf((*MockAppContract)(nil).GetConfigLoader))
 }
 
+// GetContainer mocks base method.
+func (m *MockAppContract) GetContainer() *container.Container {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContainer")
+	ret0, _ := ret[0].(*container.Container)
+	return ret0
+}
+
+// GetContainer indicates an expected call of GetContainer.
+func (mr *MockAppContractMockRecorder) GetContainer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContainer", reflect.TypeOf((*MockAppContract)(nil).GetContainer))
+}
+
 // GetDb mocks base method.
 func (m *MockAppContract) GetDb() *gorm.DB {
 	m.ctrl.T.Helper()
@@ -85,6 +152,49 @@ func (mr *MockAppContractMockRecorder) GetDb() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDb", reflect.TypeOf((*MockAppContract)(nil).GetDb))
 }
 
+// GetDbConnection mocks base method.
+func (m *MockAppContract) GetDbConnection(name string) (*gorm.DB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDbConnection", name)
+	ret0, _ := ret[0].(*gorm.DB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDbConnection indicates an expected call of GetDbConnection.
+func (mr *MockAppContractMockRecorder) GetDbConnection(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDbConnection", reflect.TypeOf((*MockAppContract)(nil).GetDbConnection), name)
+}
+
+// GetDbConnections mocks base method.
+func (m *MockAppContract) GetDbConnections() map[string]*gorm.DB {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDbConnections")
+	ret0, _ := ret[0].(map[string]*gorm.DB)
+	return ret0
+}
+
+// GetDbConnections indicates an expected call of GetDbConnections.
+func (mr *MockAppContractMockRecorder) GetDbConnections() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDbConnections", reflect.TypeOf((*MockAppContract)(nil).GetDbConnections))
+}
+
+// GetDbStats mocks base method.
+func (m *MockAppContract) GetDbStats() sql.DBStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDbStats")
+	ret0, _ := ret[0].(sql.DBStats)
+	return ret0
+}
+
+// GetDbStats indicates an expected call of GetDbStats.
+func (mr *MockAppContractMockRecorder) GetDbStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDbStats", reflect.TypeOf((*MockAppContract)(nil).GetDbStats))
+}
+
 // GetGin mocks base method.
 func (m *MockAppContract) GetGin() *gin.Engine {
 	m.ctrl.T.Helper()
@@ -99,6 +209,90 @@ func (mr *MockAppContractMockRecorder) GetGin() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGin", reflect.TypeOf((*MockAppContract)(nil).GetGin))
 }
 
+// GetHealthChecks mocks base method.
+func (m *MockAppContract) GetHealthChecks() []contracts0.HealthCheckContract {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHealthChecks")
+	ret0, _ := ret[0].([]contracts0.HealthCheckContract)
+	return ret0
+}
+
+// GetHealthChecks indicates an expected call of GetHealthChecks.
+func (mr *MockAppContractMockRecorder) GetHealthChecks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthChecks", reflect.TypeOf((*MockAppContract)(nil).GetHealthChecks))
+}
+
+// GetLogger mocks base method.
+func (m *MockAppContract) GetLogger() *zerolog.Logger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogger")
+	ret0, _ := ret[0].(*zerolog.Logger)
+	return ret0
+}
+
+// GetLogger indicates an expected call of GetLogger.
+func (mr *MockAppContractMockRecorder) GetLogger() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogger", reflect.TypeOf((*MockAppContract)(nil).GetLogger))
+}
+
+// GetMetricsRegistry mocks base method.
+func (m *MockAppContract) GetMetricsRegistry() *prometheus.Registry {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricsRegistry")
+	ret0, _ := ret[0].(*prometheus.Registry)
+	return ret0
+}
+
+// GetMetricsRegistry indicates an expected call of GetMetricsRegistry.
+func (mr *MockAppContractMockRecorder) GetMetricsRegistry() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricsRegistry", reflect.TypeOf((*MockAppContract)(nil).GetMetricsRegistry))
+}
+
+// GetMigrations mocks base method.
+func (m *MockAppContract) GetMigrations() []migration.Migration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMigrations")
+	ret0, _ := ret[0].([]migration.Migration)
+	return ret0
+}
+
+// GetMigrations indicates an expected call of GetMigrations.
+func (mr *MockAppContractMockRecorder) GetMigrations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMigrations", reflect.TypeOf((*MockAppContract)(nil).GetMigrations))
+}
+
+// GetProviders mocks base method.
+func (m *MockAppContract) GetProviders() []contracts0.ServiceProviderContract {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProviders")
+	ret0, _ := ret[0].([]contracts0.ServiceProviderContract)
+	return ret0
+}
+
+// GetProviders indicates an expected call of GetProviders.
+func (mr *MockAppContractMockRecorder) GetProviders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProviders", reflect.TypeOf((*MockAppContract)(nil).GetProviders))
+}
+
+// GetSeeders mocks base method.
+func (m *MockAppContract) GetSeeders() []contracts0.SeederContract {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeeders")
+	ret0, _ := ret[0].([]contracts0.SeederContract)
+	return ret0
+}
+
+// GetSeeders indicates an expected call of GetSeeders.
+func (mr *MockAppContractMockRecorder) GetSeeders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeeders", reflect.TypeOf((*MockAppContract)(nil).GetSeeders))
+}
+
 // GetValidator mocks base method.
 func (m *MockAppContract) GetValidator() *validator.Validate {
 	m.ctrl.T.Helper()
@@ -113,6 +307,30 @@ func (mr *MockAppContractMockRecorder) GetValidator() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidator", reflect.TypeOf((*MockAppContract)(nil).GetValidator))
 }
 
+// RegisterProvider mocks base method.
+func (m *MockAppContract) RegisterProvider(arg0 contracts0.ServiceProviderContract) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterProvider", arg0)
+}
+
+// RegisterProvider indicates an expected call of RegisterProvider.
+func (mr *MockAppContractMockRecorder) RegisterProvider(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterProvider", reflect.TypeOf((*MockAppContract)(nil).RegisterProvider), arg0)
+}
+
+// RemoveProvider mocks base method.
+func (m *MockAppContract) RemoveProvider(name string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveProvider", name)
+}
+
+// RemoveProvider indicates an expected call of RemoveProvider.
+func (mr *MockAppContractMockRecorder) RemoveProvider(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveProvider", reflect.TypeOf((*MockAppContract)(nil).RemoveProvider), name)
+}
+
 // Run mocks base method.
 func (m *MockAppContract) Run() {
 	m.ctrl.T.Helper()
@@ -137,6 +355,30 @@ func (mr *MockAppContractMockRecorder) SetConfigLoader(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetConfigLoader", reflect.TypeOf((*MockAppContract)(nil).SetConfigLoader), arg0)
 }
 
+// SetDb mocks base method.
+func (m *MockAppContract) SetDb(arg0 *gorm.DB) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDb", arg0)
+}
+
+// SetDb indicates an expected call of SetDb.
+func (mr *MockAppContractMockRecorder) SetDb(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDb", reflect.TypeOf((*MockAppContract)(nil).SetDb), arg0)
+}
+
+// SetDbConnection mocks base method.
+func (m *MockAppContract) SetDbConnection(name string, db *gorm.DB) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDbConnection", name, db)
+}
+
+// SetDbConnection indicates an expected call of SetDbConnection.
+func (mr *MockAppContractMockRecorder) SetDbConnection(name, db any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDbConnection", reflect.TypeOf((*MockAppContract)(nil).SetDbConnection), name, db)
+}
+
 // SetGin mocks base method.
 func (m *MockAppContract) SetGin(arg0 *gin.Engine) {
 	m.ctrl.T.Helper()
@@ -149,6 +391,18 @@ func (mr *MockAppContractMockRecorder) SetGin(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGin", reflect.TypeOf((*MockAppContract)(nil).SetGin), arg0)
 }
 
+// SetLogger mocks base method.
+func (m *MockAppContract) SetLogger(arg0 *zerolog.Logger) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLogger", arg0)
+}
+
+// SetLogger indicates an expected call of SetLogger.
+func (mr *MockAppContractMockRecorder) SetLogger(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLogger", reflect.TypeOf((*MockAppContract)(nil).SetLogger), arg0)
+}
+
 // SetValidator mocks base method.
 func (m *MockAppContract) SetValidator(arg0 *validator.Validate) {
 	m.ctrl.T.Helper()
@@ -162,9 +416,11 @@ func (mr *MockAppContractMockRecorder) SetValidator(arg0 any) *gomock.Call {
 }
 
 // SetupBaseDependencies mocks base method.
-func (m *MockAppContract) SetupBaseDependencies() {
+func (m *MockAppContract) SetupBaseDependencies() error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetupBaseDependencies")
+	ret := m.ctrl.Call(m, "SetupBaseDependencies")
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // SetupBaseDependencies indicates an expected call of SetupBaseDependencies.
@@ -172,3 +428,17 @@ func (mr *MockAppContractMockRecorder) SetupBaseDependencies() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetupBaseDependencies", reflect.TypeOf((*MockAppContract)(nil).SetupBaseDependencies))
 }
+
+// Shutdown mocks base method.
+func (m *MockAppContract) Shutdown() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Shutdown")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockAppContractMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockAppContract)(nil).Shutdown))
+}
@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: health_check_contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=health_check_contract.go -destination=./mocks/mock_health_check_contract.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHealthCheckContract is a mock of HealthCheckContract interface.
+type MockHealthCheckContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthCheckContractMockRecorder
+	isgomock struct{}
+}
+
+// MockHealthCheckContractMockRecorder is the mock recorder for MockHealthCheckContract.
+type MockHealthCheckContractMockRecorder struct {
+	mock *MockHealthCheckContract
+}
+
+// NewMockHealthCheckContract creates a new mock instance.
+func NewMockHealthCheckContract(ctrl *gomock.Controller) *MockHealthCheckContract {
+	mock := &MockHealthCheckContract{ctrl: ctrl}
+	mock.recorder = &MockHealthCheckContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthCheckContract) EXPECT() *MockHealthCheckContractMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockHealthCheckContract) Check(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockHealthCheckContractMockRecorder) Check(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockHealthCheckContract)(nil).Check), ctx)
+}
+
+// Name mocks base method.
+func (m *MockHealthCheckContract) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockHealthCheckContractMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockHealthCheckContract)(nil).Name))
+}
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: seeder_contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=seeder_contract.go -destination=./mocks/mock_seeder_contract.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockSeederContract is a mock of SeederContract interface.
+type MockSeederContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeederContractMockRecorder
+	isgomock struct{}
+}
+
+// MockSeederContractMockRecorder is the mock recorder for MockSeederContract.
+type MockSeederContractMockRecorder struct {
+	mock *MockSeederContract
+}
+
+// NewMockSeederContract creates a new mock instance.
+func NewMockSeederContract(ctrl *gomock.Controller) *MockSeederContract {
+	mock := &MockSeederContract{ctrl: ctrl}
+	mock.recorder = &MockSeederContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeederContract) EXPECT() *MockSeederContractMockRecorder {
+	return m.recorder
+}
+
+// DependsOn mocks base method.
+func (m *MockSeederContract) DependsOn() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DependsOn")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// DependsOn indicates an expected call of DependsOn.
+func (mr *MockSeederContractMockRecorder) DependsOn() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DependsOn", reflect.TypeOf((*MockSeederContract)(nil).DependsOn))
+}
+
+// Name mocks base method.
+func (m *MockSeederContract) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockSeederContractMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockSeederContract)(nil).Name))
+}
+
+// Run mocks base method.
+func (m *MockSeederContract) Run(db *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", db)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockSeederContractMockRecorder) Run(db any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockSeederContract)(nil).Run), db)
+}
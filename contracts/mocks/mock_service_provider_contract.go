@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service_provider_contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service_provider_contract.go -destination=./mocks/mock_service_provider_contract.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	contracts "github.com/zerpto/ponodo/contracts"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockServiceProviderContract is a mock of ServiceProviderContract interface.
+type MockServiceProviderContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceProviderContractMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceProviderContractMockRecorder is the mock recorder for MockServiceProviderContract.
+type MockServiceProviderContractMockRecorder struct {
+	mock *MockServiceProviderContract
+}
+
+// NewMockServiceProviderContract creates a new mock instance.
+func NewMockServiceProviderContract(ctrl *gomock.Controller) *MockServiceProviderContract {
+	mock := &MockServiceProviderContract{ctrl: ctrl}
+	mock.recorder = &MockServiceProviderContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceProviderContract) EXPECT() *MockServiceProviderContractMockRecorder {
+	return m.recorder
+}
+
+// Boot mocks base method.
+func (m *MockServiceProviderContract) Boot(app contracts.AppContract) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Boot", app)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Boot indicates an expected call of Boot.
+func (mr *MockServiceProviderContractMockRecorder) Boot(app any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Boot", reflect.TypeOf((*MockServiceProviderContract)(nil).Boot), app)
+}
+
+// DependsOn mocks base method.
+func (m *MockServiceProviderContract) DependsOn() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DependsOn")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// DependsOn indicates an expected call of DependsOn.
+func (mr *MockServiceProviderContractMockRecorder) DependsOn() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DependsOn", reflect.TypeOf((*MockServiceProviderContract)(nil).DependsOn))
+}
+
+// Name mocks base method.
+func (m *MockServiceProviderContract) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockServiceProviderContractMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockServiceProviderContract)(nil).Name))
+}
+
+// Register mocks base method.
+func (m *MockServiceProviderContract) Register(app contracts.AppContract) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", app)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockServiceProviderContractMockRecorder) Register(app any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockServiceProviderContract)(nil).Register), app)
+}
+
+// Shutdown mocks base method.
+func (m *MockServiceProviderContract) Shutdown(app contracts.AppContract) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Shutdown", app)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockServiceProviderContractMockRecorder) Shutdown(app any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockServiceProviderContract)(nil).Shutdown), app)
+}
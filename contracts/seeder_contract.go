@@ -0,0 +1,15 @@
+package contracts
+
+import "gorm.io/gorm"
+
+// SeederContract defines the interface for database seeders. Seeders are
+// registered on the application by name and run by the db:seed command
+// after the seeders they depend on. Run receives the default database
+// connection wrapped in a transaction.
+//
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_seeder_contract.go -package=mocks
+type SeederContract interface {
+	Name() string
+	DependsOn() []string
+	Run(db *gorm.DB) error
+}
@@ -0,0 +1,16 @@
+package contracts
+
+// ServiceProviderContract defines the interface for pluggable application
+// services. Providers are registered on the application and run through
+// three phases: Register binds services, Boot runs once every provider has
+// been registered, and Shutdown releases resources when the process exits.
+//
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_service_provider_contract.go -package=mocks
+type ServiceProviderContract interface {
+	Name() string
+	DependsOn() []string
+
+	Register(app AppContract) error
+	Boot(app AppContract) error
+	Shutdown(app AppContract) error
+}
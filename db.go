@@ -1,23 +1,184 @@
 package ponodo
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 
-	"gorm.io/driver/postgres"
+	"github.com/rs/zerolog"
+	configcontracts "github.com/zerpto/ponodo/config/contracts"
+	"github.com/zerpto/ponodo/contracts"
+	"github.com/zerpto/ponodo/metrics"
+	"github.com/zerpto/ponodo/tracing"
 	"gorm.io/gorm"
 )
 
-// NewGormConnection creates a new GORM database connection to PostgreSQL.
-// It constructs the connection string from the provided parameters and
-// returns a configured GORM DB instance ready for use.
-func NewGormConnection(host, port, user, password, dbName string) *gorm.DB {
-	if port == "" {
-		port = "5432"
+// DefaultConnection is the name of the database connection returned by
+// App.GetDb.
+const DefaultConnection = "default"
+
+// NewGormConnection creates a new GORM database connection using the driver
+// named in the configuration. The dialector is resolved through the driver
+// registry, the pool settings and read replicas are applied when the
+// configuration implements DbPoolContract and DbReplicasContract, the
+// metrics and tracing plugins are installed, and the connection is verified
+// before it is returned. Queries are logged to logger through GormLogger
+// with the slow query threshold of a DbSlowQueryContract configuration; a
+// nil logger selects the global logger. An error wrapping ErrUnknownDriver
+// or ErrDatabaseUnreachable is returned on failure. The query metrics are
+// labeled with the connection name, DefaultConnection for the default one.
+func NewGormConnection(name string, cfg configcontracts.DbConfigContract, logger *zerolog.Logger) (*gorm.DB, error) {
+	dbCfg := dbSettingsOf(cfg)
+	dialector, err := NewDialector(dbCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger: NewGormLogger(logger, dbCfg.GetSlowThreshold()),
+	})
+	if err != nil {
+		// GORM returns the connection together with a failed ping, so its
+		// pool has to be closed to stop its connection opener.
+		if db != nil {
+			if sqlDB, e := db.DB(); e == nil {
+				_ = sqlDB.Close()
+			}
+		}
+		return nil, fmt.Errorf("%w: %w", ErrDatabaseUnreachable, err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	configurePool(sqlDB, dbCfg)
+
+	if err := registerReplicas(db, dbCfg); err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+	if err := db.Use(metrics.NewGormPlugin(name)); err != nil {
+		_ = closeGormConnection(db)
+		return nil, err
+	}
+	if err := db.Use(tracing.NewGormPlugin()); err != nil {
+		_ = closeGormConnection(db)
+		return nil, err
+	}
+	return db, nil
+}
+
+// configurePool applies the optional connection pool settings. Zero values
+// keep the database/sql defaults.
+func configurePool(sqlDB *sql.DB, dbCfg dbSettings) {
+	if maxOpen := dbCfg.GetMaxOpenConns(); maxOpen > 0 {
+		sqlDB.SetMaxOpenConns(maxOpen)
+	}
+	if maxIdle := dbCfg.GetMaxIdleConns(); maxIdle > 0 {
+		sqlDB.SetMaxIdleConns(maxIdle)
+	}
+	if lifetime := dbCfg.GetConnMaxLifetime(); lifetime > 0 {
+		sqlDB.SetConnMaxLifetime(lifetime)
+	}
+	if idleTime := dbCfg.GetConnMaxIdleTime(); idleTime > 0 {
+		sqlDB.SetConnMaxIdleTime(idleTime)
+	}
+}
+
+// DatabaseServiceProvider is the built-in service provider that opens the
+// database connection described by the application configuration, along
+// with the named connections listed in DB_CONNECTIONS. It is registered by
+// NewApp under the name "database".
+type DatabaseServiceProvider struct {
+}
+
+// Name returns the name the database provider is registered under.
+func (p *DatabaseServiceProvider) Name() string {
+	return "database"
+}
+
+// DependsOn returns the providers that must be registered before the
+// database. The logger is not required, so that it can be removed: NewApp
+// registers it first, and without it queries go to the global logger.
+func (p *DatabaseServiceProvider) DependsOn() []string {
+	return nil
+}
+
+// Register opens the default and named database connections, stores them
+// on the application and binds the default connection into the application
+// container. Queries are logged through the application logger. It returns
+// ErrConfigMissing when no configuration has been loaded. When a connection fails to open, the ones already opened are
+// closed before the error is returned.
+func (p *DatabaseServiceProvider) Register(app contracts.AppContract) error {
+	loader := app.GetConfigLoader()
+	if loader == nil || loader.Config == nil {
+		return fmt.Errorf("%w: no configuration bound to the application", ErrConfigMissing)
+	}
+	dbCfg := loader.Config.GetDb()
+	if dbCfg == nil {
+		return fmt.Errorf("%w: no database configuration", ErrConfigMissing)
+	}
+	connectionCfgs, err := loader.GetDbConnections()
+	if err != nil {
+		return err
+	}
+
+	logger := app.GetLogger()
+	db, err := NewGormConnection(DefaultConnection, dbCfg, logger)
+	if err != nil {
+		return err
 	}
+	connections := make(map[string]*gorm.DB, len(connectionCfgs))
+	for name, connectionCfg := range connectionCfgs {
+		connection, err := NewGormConnection(name, connectionCfg, logger)
+		if err != nil {
+			_ = closeGormConnection(db)
+			for _, opened := range connections {
+				_ = closeGormConnection(opened)
+			}
+			return fmt.Errorf("connection %q: %w", name, err)
+		}
+		connections[name] = connection
+	}
+
+	app.SetDb(db)
+	Instance(app, db)
+	for name, connection := range connections {
+		app.SetDbConnection(name, connection)
+	}
+	return nil
+}
+
+// Boot is a no-op for the database provider.
+func (p *DatabaseServiceProvider) Boot(app contracts.AppContract) error {
+	return nil
+}
 
-	db, err := gorm.Open(postgres.Open(fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", user, password, host, port, dbName)), &gorm.Config{})
+// Shutdown closes the connection pools of every database connection and
+// of their read replicas.
+func (p *DatabaseServiceProvider) Shutdown(app contracts.AppContract) error {
+	var errs []error
+	for name, db := range app.GetDbConnections() {
+		if err := closeGormConnection(db); err != nil {
+			errs = append(errs, fmt.Errorf("connection %q: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// closeGormConnection closes the connection pool of the database together
+// with the pools of its read replicas.
+func closeGormConnection(db *gorm.DB) error {
+	replicasErr := closeReplicas(db)
+	sqlDB, err := db.DB()
 	if err != nil {
-		panic(err)
+		return errors.Join(replicasErr, err)
 	}
-	return db
+	return errors.Join(replicasErr, sqlDB.Close())
+}
+
+// NewDatabaseServiceProvider creates the built-in database service provider.
+func NewDatabaseServiceProvider() contracts.ServiceProviderContract {
+	return &DatabaseServiceProvider{}
 }
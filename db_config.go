@@ -0,0 +1,114 @@
+package ponodo
+
+import (
+	"time"
+
+	"github.com/zerpto/ponodo/config/contracts"
+)
+
+// dbSettings gives access to every setting of a database configuration,
+// including the optional ones. Settings from an optional interface the
+// configuration does not implement return their zero value, which keeps
+// the default.
+type dbSettings struct {
+	contracts.DbConfigContract
+}
+
+// dbSettingsOf wraps the configuration unless it already is dbSettings.
+func dbSettingsOf(cfg contracts.DbConfigContract) dbSettings {
+	if settings, ok := cfg.(dbSettings); ok {
+		return settings
+	}
+	return dbSettings{DbConfigContract: cfg}
+}
+
+func (s dbSettings) GetDriver() string {
+	if c, ok := s.DbConfigContract.(contracts.DbDriverContract); ok {
+		return c.GetDriver()
+	}
+	return ""
+}
+
+func (s dbSettings) GetOptions() map[string]string {
+	if c, ok := s.DbConfigContract.(contracts.DbDriverContract); ok {
+		return c.GetOptions()
+	}
+	return nil
+}
+
+func (s dbSettings) GetMaxOpenConns() int {
+	if c, ok := s.DbConfigContract.(contracts.DbPoolContract); ok {
+		return c.GetMaxOpenConns()
+	}
+	return 0
+}
+
+func (s dbSettings) GetMaxIdleConns() int {
+	if c, ok := s.DbConfigContract.(contracts.DbPoolContract); ok {
+		return c.GetMaxIdleConns()
+	}
+	return 0
+}
+
+func (s dbSettings) GetConnMaxLifetime() time.Duration {
+	if c, ok := s.DbConfigContract.(contracts.DbPoolContract); ok {
+		return c.GetConnMaxLifetime()
+	}
+	return 0
+}
+
+func (s dbSettings) GetConnMaxIdleTime() time.Duration {
+	if c, ok := s.DbConfigContract.(contracts.DbPoolContract); ok {
+		return c.GetConnMaxIdleTime()
+	}
+	return 0
+}
+
+func (s dbSettings) GetSSLMode() string {
+	if c, ok := s.DbConfigContract.(contracts.DbTLSContract); ok {
+		return c.GetSSLMode()
+	}
+	return ""
+}
+
+func (s dbSettings) GetSSLRootCert() string {
+	if c, ok := s.DbConfigContract.(contracts.DbTLSContract); ok {
+		return c.GetSSLRootCert()
+	}
+	return ""
+}
+
+func (s dbSettings) GetSSLCert() string {
+	if c, ok := s.DbConfigContract.(contracts.DbTLSContract); ok {
+		return c.GetSSLCert()
+	}
+	return ""
+}
+
+func (s dbSettings) GetSSLKey() string {
+	if c, ok := s.DbConfigContract.(contracts.DbTLSContract); ok {
+		return c.GetSSLKey()
+	}
+	return ""
+}
+
+func (s dbSettings) GetReplicas() []string {
+	if c, ok := s.DbConfigContract.(contracts.DbReplicasContract); ok {
+		return c.GetReplicas()
+	}
+	return nil
+}
+
+func (s dbSettings) GetReplicaPolicy() string {
+	if c, ok := s.DbConfigContract.(contracts.DbReplicasContract); ok {
+		return c.GetReplicaPolicy()
+	}
+	return ""
+}
+
+func (s dbSettings) GetSlowThreshold() time.Duration {
+	if c, ok := s.DbConfigContract.(contracts.DbSlowQueryContract); ok {
+		return c.GetSlowThreshold()
+	}
+	return 0
+}
@@ -0,0 +1,215 @@
+package ponodo
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/zerpto/ponodo/config/contracts"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/driver/sqlserver"
+	"gorm.io/gorm"
+)
+
+// DefaultDriver is the database driver used when the configuration does not
+// specify one.
+const DefaultDriver = "postgres"
+
+// ErrUnknownDriver is returned when the configured database driver has not
+// been registered with RegisterDriver.
+var ErrUnknownDriver = errors.New("ponodo: unknown database driver")
+
+// DialectorFactory builds a GORM dialector from the database configuration.
+// Factories are registered per driver name with RegisterDriver. The
+// configuration passed to a factory implements every optional contract,
+// such as contracts.DbDriverContract and contracts.DbTLSContract, with zero
+// values for the settings the application configuration does not provide.
+type DialectorFactory func(cfg contracts.DbConfigContract) (gorm.Dialector, error)
+
+var (
+	driversMu sync.RWMutex
+	drivers   = map[string]DialectorFactory{
+		"postgres":  newPostgresDialector,
+		"mysql":     newMysqlDialector,
+		"sqlite":    newSqliteDialector,
+		"sqlserver": newSqlserverDialector,
+	}
+)
+
+// RegisterDriver registers a dialector factory under the given driver name.
+// Registering a name that already exists replaces the previous factory, so
+// the built-in postgres, mysql, sqlite and sqlserver drivers can be overridden.
+func RegisterDriver(name string, factory DialectorFactory) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	drivers[strings.ToLower(name)] = factory
+}
+
+// Drivers returns the sorted names of all registered database drivers.
+func Drivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewDialector returns the GORM dialector for the driver named in the
+// configuration. It returns an error wrapping ErrUnknownDriver when the
+// driver has not been registered.
+func NewDialector(dbCfg contracts.DbConfigContract) (gorm.Dialector, error) {
+	cfg := dbSettingsOf(dbCfg)
+	name := strings.ToLower(cfg.GetDriver())
+	if name == "" {
+		name = DefaultDriver
+	}
+
+	driversMu.RLock()
+	factory, ok := drivers[name]
+	driversMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q (registered: %s)", ErrUnknownDriver, name, strings.Join(Drivers(), ", "))
+	}
+	return factory(cfg)
+}
+
+func newPostgresDialector(dbCfg contracts.DbConfigContract) (gorm.Dialector, error) {
+	cfg := dbSettingsOf(dbCfg)
+	query := url.Values{}
+	query.Set("sslmode", SSLModeDisable)
+	if mode := cfg.GetSSLMode(); mode != "" {
+		query.Set("sslmode", mode)
+	}
+	setIfNotEmpty(query, "sslrootcert", cfg.GetSSLRootCert())
+	setIfNotEmpty(query, "sslcert", cfg.GetSSLCert())
+	setIfNotEmpty(query, "sslkey", cfg.GetSSLKey())
+	for key, value := range cfg.GetOptions() {
+		query.Set(key, value)
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.GetUser(), cfg.GetPassword()),
+		Host:     net.JoinHostPort(cfg.GetHost(), portOrDefault(cfg.GetPort(), "5432")),
+		Path:     "/" + cfg.GetDatabase(),
+		RawQuery: query.Encode(),
+	}
+	return postgres.Open(dsn.String()), nil
+}
+
+func newMysqlDialector(dbCfg contracts.DbConfigContract) (gorm.Dialector, error) {
+	cfg := dbSettingsOf(dbCfg)
+	query := url.Values{}
+	query.Set("charset", "utf8mb4")
+	query.Set("parseTime", "True")
+	query.Set("loc", "Local")
+
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		name := mysqlTLSConfigName(cfg)
+		if err := mysqldriver.RegisterTLSConfig(name, tlsConfig); err != nil {
+			return nil, err
+		}
+		query.Set("tls", name)
+	}
+
+	for key, value := range cfg.GetOptions() {
+		query.Set(key, value)
+	}
+
+	dsn := fmt.Sprintf("%s:%s@tcp(%s)/%s?%s",
+		cfg.GetUser(),
+		cfg.GetPassword(),
+		net.JoinHostPort(cfg.GetHost(), portOrDefault(cfg.GetPort(), "3306")),
+		cfg.GetDatabase(),
+		query.Encode(),
+	)
+	return mysql.Open(dsn), nil
+}
+
+func newSqliteDialector(dbCfg contracts.DbConfigContract) (gorm.Dialector, error) {
+	cfg := dbSettingsOf(dbCfg)
+	dsn := cfg.GetDatabase()
+	if dsn == "" {
+		dsn = ":memory:"
+	}
+
+	if options := cfg.GetOptions(); len(options) > 0 {
+		query := url.Values{}
+		for key, value := range options {
+			query.Set(key, value)
+		}
+		separator := "?"
+		if strings.Contains(dsn, "?") {
+			separator = "&"
+		}
+		dsn += separator + query.Encode()
+	}
+	return sqlite.Open(dsn), nil
+}
+
+func newSqlserverDialector(dbCfg contracts.DbConfigContract) (gorm.Dialector, error) {
+	cfg := dbSettingsOf(dbCfg)
+	query := url.Values{}
+	query.Set("database", cfg.GetDatabase())
+	switch cfg.GetSSLMode() {
+	case "":
+	case SSLModeDisable:
+		query.Set("encrypt", "disable")
+	case SSLModeRequire:
+		query.Set("encrypt", "true")
+		query.Set("TrustServerCertificate", "true")
+	case SSLModeVerifyCA, SSLModeVerifyFull:
+		query.Set("encrypt", "true")
+		setIfNotEmpty(query, "certificate", cfg.GetSSLRootCert())
+		if cfg.GetSSLMode() == SSLModeVerifyFull {
+			query.Set("hostNameInCertificate", cfg.GetHost())
+		}
+	default:
+		return nil, fmt.Errorf("unsupported ssl mode %q", cfg.GetSSLMode())
+	}
+	for key, value := range cfg.GetOptions() {
+		query.Set(key, value)
+	}
+
+	dsn := url.URL{
+		Scheme:   "sqlserver",
+		User:     url.UserPassword(cfg.GetUser(), cfg.GetPassword()),
+		Host:     net.JoinHostPort(cfg.GetHost(), portOrDefault(cfg.GetPort(), "1433")),
+		RawQuery: query.Encode(),
+	}
+	return sqlserver.Open(dsn.String()), nil
+}
+
+func mysqlTLSConfigName(cfg dbSettings) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(cfg.GetHost() + ":" + cfg.GetPort() + "/" + cfg.GetDatabase()))
+	return fmt.Sprintf("ponodo-%x", hash.Sum32())
+}
+
+func setIfNotEmpty(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+func portOrDefault(port, fallback string) string {
+	if port == "" {
+		return fallback
+	}
+	return port
+}
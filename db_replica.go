@@ -0,0 +1,107 @@
+package ponodo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// Replica selection policies accepted by DbReplicasContract.GetReplicaPolicy.
+const (
+	ReplicaPolicyRandom     = "random"
+	ReplicaPolicyRoundRobin = "round_robin"
+)
+
+// replicaConfig reuses the primary configuration for a replica, replacing
+// only the host and port.
+type replicaConfig struct {
+	dbSettings
+	host string
+	port string
+}
+
+func (c *replicaConfig) GetHost() string { return c.host }
+func (c *replicaConfig) GetPort() string { return c.port }
+
+// registerReplicas routes read queries to the configured replicas while
+// writes and transactions keep using the primary connection.
+func registerReplicas(db *gorm.DB, dbCfg dbSettings) error {
+	replicas := dbCfg.GetReplicas()
+	if len(replicas) == 0 {
+		return nil
+	}
+
+	policy, err := newReplicaPolicy(dbCfg.GetReplicaPolicy())
+	if err != nil {
+		return err
+	}
+
+	dialectors := make([]gorm.Dialector, 0, len(replicas))
+	for _, replica := range replicas {
+		host, port, err := net.SplitHostPort(replica)
+		if err != nil {
+			host, port = replica, dbCfg.GetPort()
+		}
+
+		dialector, err := NewDialector(&replicaConfig{dbSettings: dbCfg, host: host, port: port})
+		if err != nil {
+			return err
+		}
+		dialectors = append(dialectors, dialector)
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: dialectors,
+		Policy:   policy,
+	})
+	if maxOpen := dbCfg.GetMaxOpenConns(); maxOpen > 0 {
+		resolver.SetMaxOpenConns(maxOpen)
+	}
+	if maxIdle := dbCfg.GetMaxIdleConns(); maxIdle > 0 {
+		resolver.SetMaxIdleConns(maxIdle)
+	}
+	if lifetime := dbCfg.GetConnMaxLifetime(); lifetime > 0 {
+		resolver.SetConnMaxLifetime(lifetime)
+	}
+	if idleTime := dbCfg.GetConnMaxIdleTime(); idleTime > 0 {
+		resolver.SetConnMaxIdleTime(idleTime)
+	}
+
+	if err := db.Use(resolver); err != nil {
+		return fmt.Errorf("%w: replica: %w", ErrDatabaseUnreachable, err)
+	}
+	return nil
+}
+
+// closeReplicas closes the connection pools opened for the read replicas of
+// the database. Closing a pool twice is harmless, so the primary pool the
+// resolver also holds may be closed again afterwards.
+func closeReplicas(db *gorm.DB) error {
+	resolver, ok := db.Config.Plugins[(&dbresolver.DBResolver{}).Name()].(*dbresolver.DBResolver)
+	if !ok {
+		return nil
+	}
+	var errs []error
+	_ = resolver.Call(func(connPool gorm.ConnPool) error {
+		if closer, ok := connPool.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+		return nil
+	})
+	return errors.Join(errs...)
+}
+
+func newReplicaPolicy(name string) (dbresolver.Policy, error) {
+	switch name {
+	case "", ReplicaPolicyRandom:
+		return dbresolver.RandomPolicy{}, nil
+	case ReplicaPolicyRoundRobin:
+		return dbresolver.StrictRoundRobinPolicy(), nil
+	default:
+		return nil, fmt.Errorf("unsupported replica policy %q", name)
+	}
+}
@@ -1,7 +1,25 @@
 package ponodo
 
 import (
+	"bytes"
+	"database/sql"
+	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/zerpto/ponodo/config"
+	configcontracts "github.com/zerpto/ponodo/config/contracts"
+	configmocks "github.com/zerpto/ponodo/config/contracts/mocks"
+	"github.com/zerpto/ponodo/metrics"
 )
 
 func TestNewGormConnection(t *testing.T) {
@@ -11,3 +29,313 @@ func TestNewGormConnection(t *testing.T) {
 func TestNewGormConnection_DefaultPort(t *testing.T) {
 	t.Skip("Skipping database-related test - requires database connection")
 }
+
+func TestNewGormConnection_Unreachable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbCfg := newMockDbConfig(ctrl, testDbSettings{driver: "postgres", host: "127.0.0.1", port: "1", database: "db"})
+
+	db, err := NewGormConnection(DefaultConnection, dbCfg, nil)
+	require.Error(t, err)
+	assert.Nil(t, db)
+	assert.ErrorIs(t, err, ErrDatabaseUnreachable)
+}
+
+// lazyDialector connects without touching the database, so that only the
+// ping run by gorm.Open fails.
+type lazyDialector struct {
+	gorm.Dialector
+	conn *sql.DB
+}
+
+func (d lazyDialector) Initialize(db *gorm.DB) error {
+	db.ConnPool = d.conn
+	return nil
+}
+
+func TestNewGormConnection_UnreachableClosesPool(t *testing.T) {
+	sqlDB, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "missing", "app.db"))
+	require.NoError(t, err)
+	RegisterDriver("unreachable", func(configcontracts.DbConfigContract) (gorm.Dialector, error) {
+		return lazyDialector{Dialector: sqlite.New(sqlite.Config{Conn: sqlDB}), conn: sqlDB}, nil
+	})
+	defer func() {
+		driversMu.Lock()
+		delete(drivers, "unreachable")
+		driversMu.Unlock()
+	}()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db, err := NewGormConnection(DefaultConnection, newMockDbConfig(ctrl, testDbSettings{driver: "unreachable"}), nil)
+	assert.ErrorIs(t, err, ErrDatabaseUnreachable)
+	assert.Nil(t, db)
+	assert.ErrorContains(t, sqlDB.Ping(), "database is closed")
+}
+
+func TestNewGormConnection_Sqlite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbCfg := newMockDbConfig(ctrl, testDbSettings{driver: "sqlite", database: ":memory:"})
+
+	db, err := NewGormConnection("reporting", dbCfg, nil)
+	require.NoError(t, err)
+
+	var result int
+	require.NoError(t, db.Raw("SELECT 1").Scan(&result).Error)
+	assert.Equal(t, 1, result)
+	require.Contains(t, db.Config.Plugins, metrics.GormPluginName)
+	plugin, ok := db.Config.Plugins[metrics.GormPluginName].(*metrics.GormPlugin)
+	require.True(t, ok)
+	assert.Equal(t, "reporting", plugin.Connection)
+}
+
+func TestNewGormConnection_Pool(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbCfg := newMockDbConfig(ctrl, testDbSettings{
+		driver:          "sqlite",
+		database:        ":memory:",
+		maxOpenConns:    3,
+		maxIdleConns:    2,
+		connMaxLifetime: time.Minute,
+	})
+
+	db, err := NewGormConnection(DefaultConnection, dbCfg, nil)
+	require.NoError(t, err)
+
+	app := &App{}
+	app.SetDb(db)
+	assert.Equal(t, 3, app.GetDbStats().MaxOpenConnections)
+}
+
+func TestNewGormConnection_Replicas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbCfg := newMockDbConfig(ctrl, testDbSettings{
+		driver:        "sqlite",
+		database:      ":memory:",
+		replicas:      []string{"replica-1", "replica-2:5432"},
+		replicaPolicy: ReplicaPolicyRoundRobin,
+	})
+
+	db, err := NewGormConnection(DefaultConnection, dbCfg, nil)
+	require.NoError(t, err)
+
+	var result int
+	require.NoError(t, db.Raw("SELECT 1").Scan(&result).Error)
+	assert.Equal(t, 1, result)
+}
+
+func TestNewGormConnection_UnsupportedReplicaPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbCfg := newMockDbConfig(ctrl, testDbSettings{
+		driver:        "sqlite",
+		database:      ":memory:",
+		replicas:      []string{"replica-1"},
+		replicaPolicy: "nearest",
+	})
+
+	db, err := NewGormConnection(DefaultConnection, dbCfg, nil)
+	require.Error(t, err)
+	assert.Nil(t, db)
+}
+
+func TestDatabaseServiceProvider_Register_ClosesOnFailure(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("db_connections", "broken")
+	viper.Set("db_broken_driver", "oracle")
+
+	sqlDB, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "primary.db"))
+	require.NoError(t, err)
+	RegisterDriver("tracked", func(configcontracts.DbConfigContract) (gorm.Dialector, error) {
+		return sqlite.New(sqlite.Config{Conn: sqlDB}), nil
+	})
+	defer func() {
+		driversMu.Lock()
+		delete(drivers, "tracked")
+		driversMu.Unlock()
+	}()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockConfig := configmocks.NewMockConfigContract(ctrl)
+	mockConfig.EXPECT().GetDb().Return(&config.DbConfig{Driver: "tracked"}).AnyTimes()
+
+	app := &App{ConfigLoader: &config.Loader{Config: mockConfig}}
+	err = NewDatabaseServiceProvider().Register(app)
+	assert.ErrorIs(t, err, ErrUnknownDriver)
+
+	assert.Nil(t, app.GetDb())
+	assert.Empty(t, app.GetDbConnections())
+	assert.ErrorContains(t, sqlDB.Ping(), "database is closed")
+}
+
+func TestDatabaseServiceProvider_Register_UsesAppLogger(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockConfig := configmocks.NewMockConfigContract(ctrl)
+	mockConfig.EXPECT().GetDb().Return(&config.DbConfig{
+		Driver:   "sqlite",
+		Database: filepath.Join(t.TempDir(), "app.db"),
+	}).AnyTimes()
+
+	buf := &bytes.Buffer{}
+	logger := zerolog.New(buf).Level(zerolog.DebugLevel)
+	app := &App{ConfigLoader: &config.Loader{Config: mockConfig}, Logger: &logger}
+
+	provider := NewDatabaseServiceProvider()
+	require.NoError(t, provider.Register(app))
+	defer func() { _ = provider.Shutdown(app) }()
+
+	var result int
+	require.NoError(t, app.GetDb().Raw("SELECT 1").Scan(&result).Error)
+	assert.Contains(t, buf.String(), `"sql":"SELECT 1"`)
+}
+
+func TestDatabaseServiceProvider_Shutdown_ClosesReplicas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+	db, err := NewGormConnection(DefaultConnection, newMockDbConfig(ctrl, testDbSettings{
+		driver:   "sqlite",
+		database: filepath.Join(dir, "primary.db"),
+		replicas: []string{"replica-1"},
+	}), nil)
+	require.NoError(t, err)
+
+	resolver := db.Config.Plugins[(&dbresolver.DBResolver{}).Name()].(*dbresolver.DBResolver)
+	var pools []*sql.DB
+	require.NoError(t, resolver.Call(func(connPool gorm.ConnPool) error {
+		if pool, ok := connPool.(*sql.DB); ok {
+			pools = append(pools, pool)
+		}
+		return nil
+	}))
+	require.Len(t, pools, 2)
+
+	app := &App{}
+	app.SetDb(db)
+	require.NoError(t, NewDatabaseServiceProvider().Shutdown(app))
+	for _, pool := range pools {
+		assert.ErrorContains(t, pool.Ping(), "database is closed")
+	}
+}
+
+func TestApp_GetDbConnection(t *testing.T) {
+	app := &App{}
+	primary := &gorm.DB{}
+	analytics := &gorm.DB{}
+
+	_, err := app.GetDbConnection(DefaultConnection)
+	assert.ErrorIs(t, err, ErrConnectionNotFound)
+
+	app.SetDbConnection(DefaultConnection, primary)
+	app.SetDbConnection("analytics", analytics)
+
+	db, err := app.GetDbConnection(DefaultConnection)
+	require.NoError(t, err)
+	assert.Same(t, primary, db)
+	assert.Same(t, primary, app.GetDb())
+
+	db, err = app.GetDbConnection("analytics")
+	require.NoError(t, err)
+	assert.Same(t, analytics, db)
+
+	_, err = app.GetDbConnection("reporting")
+	assert.ErrorIs(t, err, ErrConnectionNotFound)
+
+	assert.Len(t, app.GetDbConnections(), 2)
+}
+
+func TestApp_GetDbStats_NoDb(t *testing.T) {
+	app := &App{}
+	assert.Equal(t, 0, app.GetDbStats().MaxOpenConnections)
+}
+
+func TestNewGormConnection_UnknownDriver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbCfg := newMockDbConfig(ctrl, testDbSettings{driver: "oracle", host: "localhost", database: "db"})
+
+	db, err := NewGormConnection(DefaultConnection, dbCfg, nil)
+	require.Error(t, err)
+	assert.Nil(t, db)
+	assert.ErrorIs(t, err, ErrUnknownDriver)
+}
+
+type testDbSettings struct {
+	driver          string
+	host            string
+	port            string
+	database        string
+	options         map[string]string
+	maxOpenConns    int
+	maxIdleConns    int
+	connMaxLifetime time.Duration
+	connMaxIdleTime time.Duration
+	sslMode         string
+	sslRootCert     string
+	sslCert         string
+	sslKey          string
+	replicas        []string
+	replicaPolicy   string
+}
+
+// mockDbConfig implements the database configuration contract together
+// with every optional one.
+type mockDbConfig struct {
+	*configmocks.MockDbConfigContract
+	*configmocks.MockDbDriverContract
+	*configmocks.MockDbPoolContract
+	*configmocks.MockDbTLSContract
+	*configmocks.MockDbReplicasContract
+	*configmocks.MockDbSlowQueryContract
+}
+
+func newMockDbConfig(ctrl *gomock.Controller, settings testDbSettings) *mockDbConfig {
+	base := configmocks.NewMockDbConfigContract(ctrl)
+	base.EXPECT().GetHost().Return(settings.host).AnyTimes()
+	base.EXPECT().GetPort().Return(settings.port).AnyTimes()
+	base.EXPECT().GetUser().Return("user").AnyTimes()
+	base.EXPECT().GetPassword().Return("p@ss").AnyTimes()
+	base.EXPECT().GetDatabase().Return(settings.database).AnyTimes()
+
+	driver := configmocks.NewMockDbDriverContract(ctrl)
+	driver.EXPECT().GetDriver().Return(settings.driver).AnyTimes()
+	driver.EXPECT().GetOptions().Return(settings.options).AnyTimes()
+
+	pool := configmocks.NewMockDbPoolContract(ctrl)
+	pool.EXPECT().GetMaxOpenConns().Return(settings.maxOpenConns).AnyTimes()
+	pool.EXPECT().GetMaxIdleConns().Return(settings.maxIdleConns).AnyTimes()
+	pool.EXPECT().GetConnMaxLifetime().Return(settings.connMaxLifetime).AnyTimes()
+	pool.EXPECT().GetConnMaxIdleTime().Return(settings.connMaxIdleTime).AnyTimes()
+
+	tlsCfg := configmocks.NewMockDbTLSContract(ctrl)
+	tlsCfg.EXPECT().GetSSLMode().Return(settings.sslMode).AnyTimes()
+	tlsCfg.EXPECT().GetSSLRootCert().Return(settings.sslRootCert).AnyTimes()
+	tlsCfg.EXPECT().GetSSLCert().Return(settings.sslCert).AnyTimes()
+	tlsCfg.EXPECT().GetSSLKey().Return(settings.sslKey).AnyTimes()
+
+	replicas := configmocks.NewMockDbReplicasContract(ctrl)
+	replicas.EXPECT().GetReplicas().Return(settings.replicas).AnyTimes()
+	replicas.EXPECT().GetReplicaPolicy().Return(settings.replicaPolicy).AnyTimes()
+
+	slowQuery := configmocks.NewMockDbSlowQueryContract(ctrl)
+	slowQuery.EXPECT().GetSlowThreshold().Return(time.Duration(0)).AnyTimes()
+
+	return &mockDbConfig{base, driver, pool, tlsCfg, replicas, slowQuery}
+}
@@ -0,0 +1,92 @@
+package ponodo
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// SSL modes understood by the built-in drivers. They follow the PostgreSQL
+// sslmode semantics and are translated for the other drivers.
+const (
+	SSLModeDisable    = "disable"
+	SSLModeRequire    = "require"
+	SSLModeVerifyCA   = "verify-ca"
+	SSLModeVerifyFull = "verify-full"
+)
+
+// newTLSConfig builds a tls.Config from the SSL settings of the database
+// configuration. It returns nil when TLS is disabled or not configured.
+func newTLSConfig(cfg dbSettings) (*tls.Config, error) {
+	mode := cfg.GetSSLMode()
+	if mode == "" || mode == SSLModeDisable {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.GetHost(),
+	}
+
+	if rootCert := cfg.GetSSLRootCert(); rootCert != "" {
+		pem, err := os.ReadFile(rootCert)
+		if err != nil {
+			return nil, fmt.Errorf("read ssl root certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("ssl root certificate %s contains no certificates", rootCert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.GetSSLCert() != "" || cfg.GetSSLKey() != "" {
+		certificate, err := tls.LoadX509KeyPair(cfg.GetSSLCert(), cfg.GetSSLKey())
+		if err != nil {
+			return nil, fmt.Errorf("load ssl client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	switch mode {
+	case SSLModeRequire:
+		tlsConfig.InsecureSkipVerify = true
+	case SSLModeVerifyCA:
+		// Verify the chain against the configured roots but not the host name.
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyPeerCertificate = verifyChainOnly(tlsConfig.RootCAs)
+	case SSLModeVerifyFull:
+	default:
+		return nil, fmt.Errorf("unsupported ssl mode %q", mode)
+	}
+	return tlsConfig, nil
+}
+
+func verifyChainOnly(roots *x509.CertPool) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("server presented no certificates")
+		}
+
+		certificates := make([]*x509.Certificate, len(rawCerts))
+		for i, raw := range rawCerts {
+			certificate, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return err
+			}
+			certificates[i] = certificate
+		}
+
+		intermediates := x509.NewCertPool()
+		for _, certificate := range certificates[1:] {
+			intermediates.AddCert(certificate)
+		}
+		_, err := certificates[0].Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+		})
+		return err
+	}
+}
@@ -0,0 +1,52 @@
+package ponodo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zerpto/ponodo/config"
+)
+
+var (
+	// ErrConfigMissing is returned when the application is set up without a
+	// configuration loader or without a bound configuration. It is the same
+	// error returned by config.NewLoader when the .env file does not exist.
+	ErrConfigMissing = config.ErrConfigMissing
+
+	// ErrDatabaseUnreachable is returned when the database connection cannot
+	// be opened or does not answer the initial ping.
+	ErrDatabaseUnreachable = errors.New("ponodo: database unreachable")
+
+	// ErrConnectionNotFound is returned when a named database connection
+	// has not been configured.
+	ErrConnectionNotFound = errors.New("ponodo: database connection not found")
+)
+
+// ProviderPhase identifies the lifecycle phase a service provider failed in.
+type ProviderPhase string
+
+const (
+	ProviderPhaseRegister ProviderPhase = "register"
+	ProviderPhaseBoot     ProviderPhase = "boot"
+	ProviderPhaseShutdown ProviderPhase = "shutdown"
+)
+
+// ProviderError reports a service provider that failed during one of its
+// lifecycle phases. The underlying error is available through errors.Is
+// and errors.As, so callers can still match ErrDatabaseUnreachable or
+// ErrConfigMissing.
+type ProviderError struct {
+	Provider string
+	Phase    ProviderPhase
+	Err      error
+}
+
+// Error returns the error message including the provider and phase.
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("%s provider %q: %v", e.Phase, e.Provider, e.Err)
+}
+
+// Unwrap returns the underlying provider error.
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
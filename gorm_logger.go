@@ -0,0 +1,165 @@
+package ponodo
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/zerpto/ponodo/middleware"
+)
+
+// GormLogger is a GORM logger that writes through zerolog instead of
+// standard output. Every query is logged at the debug level, queries
+// slower than SlowThreshold at the warn level and failed queries at the
+// error level, so LOG_LEVEL decides how much SQL reaches the logs.
+// Parameter values are never logged; the SQL keeps its placeholders.
+//
+// Entries are written with the logger attached to the query context by the
+// access log middleware, so queries run with db.WithContext(ctx) carry the
+// request ID of the request. Other queries use Logger, with the request ID
+// added when the context carries one.
+type GormLogger struct {
+	// Logger is used when the query context carries no logger. The global
+	// logger is used when it is nil.
+	Logger *zerolog.Logger
+
+	// SlowThreshold is the duration above which a query is logged as slow.
+	// Zero disables slow query logging.
+	SlowThreshold time.Duration
+
+	// LogLevel is the GORM log level set through LogMode. Queries are only
+	// logged at the Info level, slow queries from the Warn level and
+	// failed queries from the Error level.
+	LogLevel gormlogger.LogLevel
+}
+
+// NewGormLogger creates a GORM logger writing through logger with the given
+// slow query threshold. The global logger is used when logger is nil.
+func NewGormLogger(logger *zerolog.Logger, slowThreshold time.Duration) *GormLogger {
+	return &GormLogger{
+		Logger:        logger,
+		SlowThreshold: slowThreshold,
+		LogLevel:      gormlogger.Info,
+	}
+}
+
+// LogMode returns a copy of the logger using the given GORM log level.
+func (l *GormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	logger := *l
+	logger.LogLevel = level
+	return &logger
+}
+
+// Info logs a GORM message at the info level.
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.LogLevel >= gormlogger.Info {
+		ctx = requestContext(ctx)
+		l.logger(ctx).Info().Ctx(ctx).Msgf(msg, args...)
+	}
+}
+
+// Warn logs a GORM message at the warn level.
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.LogLevel >= gormlogger.Warn {
+		ctx = requestContext(ctx)
+		l.logger(ctx).Warn().Ctx(ctx).Msgf(msg, args...)
+	}
+}
+
+// Error logs a GORM message at the error level.
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.LogLevel >= gormlogger.Error {
+		ctx = requestContext(ctx)
+		l.logger(ctx).Error().Ctx(ctx).Msgf(msg, args...)
+	}
+}
+
+// Trace logs an executed query with its duration and affected rows. A
+// missing record is not treated as a failure.
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.LogLevel <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	ctx = requestContext(ctx)
+	logger := l.logger(ctx)
+	var event *zerolog.Event
+	msg := "query"
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.LogLevel >= gormlogger.Error:
+		event = logger.Error().Err(err)
+		msg = "query failed"
+	case l.SlowThreshold > 0 && elapsed > l.SlowThreshold && l.LogLevel >= gormlogger.Warn:
+		event = logger.Warn().Dur("threshold", l.SlowThreshold)
+		msg = "slow query"
+	case l.LogLevel >= gormlogger.Info:
+		event = logger.Debug()
+	default:
+		return
+	}
+	if !event.Enabled() {
+		return
+	}
+
+	sql, rows := fc()
+	event = event.Ctx(ctx).
+		Str("sql", sql).
+		Dur("duration", elapsed).
+		Str("source", querySource())
+	if rows >= 0 {
+		event = event.Int64("rows", rows)
+	}
+	event.Msg(msg)
+}
+
+// ParamsFilter drops the query parameters so that their values, which may
+// hold personal data or secrets, are never written to the logs.
+func (l *GormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
+
+// logger returns the logger for a query: the one attached to its context
+// when there is one, otherwise Logger or the global logger with the request
+// ID of the context.
+func (l *GormLogger) logger(ctx context.Context) *zerolog.Logger {
+	if logger := zerolog.Ctx(ctx); logger.GetLevel() != zerolog.Disabled {
+		return logger
+	}
+
+	logger := l.Logger
+	if logger == nil {
+		logger = &log.Logger
+	}
+	if id := middleware.RequestIDFromContext(ctx); id != "" {
+		child := logger.With().Str("request_id", id).Logger()
+		return &child
+	}
+	return logger
+}
+
+// querySource returns the file and line of the code that ran the query,
+// skipping the frames of GORM, its drivers and plugins. It must be called
+// directly from Trace.
+func querySource() string {
+	pcs := [16]uintptr{}
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		internal := strings.Contains(frame.File, "/gorm.io/") && !strings.HasSuffix(frame.File, "_test.go")
+		if frame.File != "" && !internal && !strings.HasSuffix(frame.File, ".gen.go") {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package ponodo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/zerpto/ponodo/middleware"
+)
+
+type gormLoggerUser struct {
+	ID    uint
+	Email string
+}
+
+func newGormLoggerDB(t *testing.T, slowThreshold time.Duration) (*gorm.DB, *bytes.Buffer) {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	base := zerolog.New(buf).Level(zerolog.DebugLevel)
+	gormLogger := NewGormLogger(&base, slowThreshold)
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "logger.db")), &gorm.Config{Logger: gormLogger})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&gormLoggerUser{}))
+	buf.Reset()
+	return db, buf
+}
+
+func decodeGormLog(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestGormLogger_RedactsParameters(t *testing.T) {
+	db, buf := newGormLoggerDB(t, 0)
+
+	require.NoError(t, db.Create(&gormLoggerUser{Email: "secret@example.com"}).Error)
+
+	entries := decodeGormLog(t, buf)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "debug", entries[0]["level"])
+	assert.Equal(t, "query", entries[0]["message"])
+	assert.Contains(t, entries[0]["sql"], "INSERT INTO")
+	assert.Contains(t, entries[0]["sql"], "?")
+	assert.NotContains(t, buf.String(), "secret@example.com")
+	assert.EqualValues(t, 1, entries[0]["rows"])
+	assert.Contains(t, entries[0]["source"], "gorm_logger_test.go")
+	assert.Contains(t, entries[0], "duration")
+}
+
+func TestGormLogger_SlowQuery(t *testing.T) {
+	db, buf := newGormLoggerDB(t, time.Nanosecond)
+
+	var users []gormLoggerUser
+	require.NoError(t, db.Find(&users).Error)
+
+	entries := decodeGormLog(t, buf)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "warn", entries[0]["level"])
+	assert.Equal(t, "slow query", entries[0]["message"])
+	assert.Contains(t, entries[0], "threshold")
+}
+
+func TestGormLogger_Errors(t *testing.T) {
+	db, buf := newGormLoggerDB(t, 0)
+
+	var user gormLoggerUser
+	assert.ErrorIs(t, db.First(&user).Error, gorm.ErrRecordNotFound)
+	assert.Error(t, db.Table("missing").Find(&[]gormLoggerUser{}).Error)
+
+	entries := decodeGormLog(t, buf)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "debug", entries[0]["level"])
+	assert.Equal(t, "error", entries[1]["level"])
+	assert.Equal(t, "query failed", entries[1]["message"])
+	assert.Contains(t, entries[1]["error"], "no such table")
+}
+
+func TestGormLogger_RequestID(t *testing.T) {
+	db, buf := newGormLoggerDB(t, 0)
+
+	ctx := middleware.WithRequestID(context.Background(), "req-123")
+	require.NoError(t, db.WithContext(ctx).Find(&[]gormLoggerUser{}).Error)
+
+	requestBuf := &bytes.Buffer{}
+	requestLogger := zerolog.New(requestBuf).With().Str("request_id", "req-456").Logger()
+	ctx = requestLogger.WithContext(context.Background())
+	require.NoError(t, db.WithContext(ctx).Find(&[]gormLoggerUser{}).Error)
+
+	entries := decodeGormLog(t, buf)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "req-123", entries[0]["request_id"])
+
+	entries = decodeGormLog(t, requestBuf)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "req-456", entries[0]["request_id"])
+}
+
+func TestGormLogger_LogMode(t *testing.T) {
+	db, buf := newGormLoggerDB(t, time.Nanosecond)
+
+	quiet := db.Session(&gorm.Session{Logger: db.Logger.LogMode(gormlogger.Error)})
+	require.NoError(t, quiet.Find(&[]gormLoggerUser{}).Error)
+	silent := db.Session(&gorm.Session{Logger: db.Logger.LogMode(gormlogger.Silent)})
+	assert.Error(t, silent.Table("missing").Find(&[]gormLoggerUser{}).Error)
+
+	assert.Empty(t, buf.String())
+}
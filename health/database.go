@@ -0,0 +1,43 @@
+package health
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zerpto/ponodo/contracts"
+)
+
+// DatabaseCheckName is the name of the check returned by DatabaseCheck.
+const DatabaseCheckName = "database"
+
+// ErrNoDatabase is reported by the database check when the application has
+// no database connection.
+var ErrNoDatabase = errors.New("health: no database connection")
+
+type databaseCheck struct {
+	app contracts.AppContract
+}
+
+// DatabaseCheck returns a check pinging the default database connection of
+// the application, as returned by GetDb at the time of the check.
+func DatabaseCheck(app contracts.AppContract) contracts.HealthCheckContract {
+	return &databaseCheck{
+		app: app,
+	}
+}
+
+func (c *databaseCheck) Name() string {
+	return DatabaseCheckName
+}
+
+func (c *databaseCheck) Check(ctx context.Context) error {
+	db := c.app.GetDb()
+	if db == nil {
+		return ErrNoDatabase
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,46 @@
+package health
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/zerpto/ponodo/contracts/mocks"
+)
+
+func TestDatabaseCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "health.db")), &gorm.Config{})
+	require.NoError(t, err)
+
+	mockApp := mocks.NewMockAppContract(ctrl)
+	mockApp.EXPECT().GetDb().Return(db).AnyTimes()
+
+	check := DatabaseCheck(mockApp)
+	assert.Equal(t, DatabaseCheckName, check.Name())
+	assert.NoError(t, check.Check(context.Background()))
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+	assert.Error(t, check.Check(context.Background()))
+}
+
+func TestDatabaseCheck_WithoutDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockApp := mocks.NewMockAppContract(ctrl)
+	mockApp.EXPECT().GetDb().Return(nil)
+
+	err := DatabaseCheck(mockApp).Check(context.Background())
+	assert.ErrorIs(t, err, ErrNoDatabase)
+}
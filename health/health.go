@@ -0,0 +1,182 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zerpto/ponodo/contracts"
+)
+
+// Statuses reported for the service and for every check.
+const (
+	StatusOK           = "ok"
+	StatusFail         = "fail"
+	StatusShuttingDown = "shutting_down"
+)
+
+// Paths of the endpoints mounted by Register.
+const (
+	HealthPath    = "/healthz"
+	ReadinessPath = "/readyz"
+	LivenessPath  = "/livez"
+)
+
+// DefaultTimeout is the time a single check may take before it is reported
+// as failing.
+const DefaultTimeout = 5 * time.Second
+
+// CheckResult is the outcome of a single health check.
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the JSON body of the health endpoints. Status is ok only when
+// every check passed.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// Health runs the registered checks and serves the liveness, readiness and
+// health endpoints. Readiness fails as soon as SetShuttingDown is called,
+// so load balancers stop routing traffic while in-flight requests drain.
+type Health struct {
+	// Timeout bounds every check, DefaultTimeout when zero.
+	Timeout time.Duration
+
+	checks       []contracts.HealthCheckContract
+	shuttingDown atomic.Bool
+}
+
+// New creates a health subsystem running the given checks.
+func New(checks ...contracts.HealthCheckContract) *Health {
+	return &Health{
+		checks: checks,
+	}
+}
+
+// SetShuttingDown marks the service as shutting down, which makes the
+// readiness endpoint fail from then on.
+func (h *Health) SetShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+// ShuttingDown reports whether SetShuttingDown has been called.
+func (h *Health) ShuttingDown() bool {
+	return h.shuttingDown.Load()
+}
+
+// Check runs every check concurrently and returns the report. A check that
+// does not return within the timeout is reported as failing.
+func (h *Health) Check(ctx context.Context) Report {
+	timeout := h.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	report := Report{
+		Status: StatusOK,
+		Checks: make(map[string]CheckResult, len(h.checks)),
+	}
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, check := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := run(ctx, check, timeout)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.Name()] = result
+			if result.Status != StatusOK {
+				report.Status = StatusFail
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+// run executes a single check. The check runs in its own goroutine so that
+// one ignoring its context cannot hold up the report.
+func run(ctx context.Context, check contracts.HealthCheckContract, timeout time.Duration) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		done <- check.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := CheckResult{
+		Status:    StatusOK,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Register mounts the health, readiness and liveness endpoints.
+func (h *Health) Register(r gin.IRoutes) {
+	r.GET(HealthPath, h.HealthHandler)
+	r.GET(ReadinessPath, h.ReadinessHandler)
+	r.GET(LivenessPath, h.LivenessHandler)
+}
+
+// HealthHandler responds with the report of every check, with 200 OK when
+// all of them pass and 503 Service Unavailable otherwise.
+func (h *Health) HealthHandler(ctx *gin.Context) {
+	respond(ctx, h.Check(ctx.Request.Context()))
+}
+
+// ReadinessHandler responds like HealthHandler, but fails without running
+// the checks once the service is shutting down.
+func (h *Health) ReadinessHandler(ctx *gin.Context) {
+	if h.ShuttingDown() {
+		respond(ctx, Report{Status: StatusShuttingDown})
+		return
+	}
+	respond(ctx, h.Check(ctx.Request.Context()))
+}
+
+// LivenessHandler responds with 200 OK as long as the process serves
+// requests. It runs no checks, so a failing dependency does not get the
+// process restarted.
+func (h *Health) LivenessHandler(ctx *gin.Context) {
+	respond(ctx, Report{Status: StatusOK})
+}
+
+func respond(ctx *gin.Context, report Report) {
+	status := http.StatusOK
+	if report.Status != StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(status, report)
+}
@@ -0,0 +1,119 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+func (c *testCheck) Name() string                    { return c.name }
+func (c *testCheck) Check(ctx context.Context) error { return c.check(ctx) }
+
+func passing(name string) *testCheck {
+	return &testCheck{name: name, check: func(context.Context) error { return nil }}
+}
+
+func failing(name string, err error) *testCheck {
+	return &testCheck{name: name, check: func(context.Context) error { return err }}
+}
+
+func serve(t *testing.T, h *Health, path string) (int, Report) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	h.Register(r)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var report Report
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	return w.Code, report
+}
+
+func TestHealth_Check(t *testing.T) {
+	h := New(passing("cache"), failing("queue", errors.New("connection refused")))
+
+	report := h.Check(context.Background())
+	assert.Equal(t, StatusFail, report.Status)
+	require.Len(t, report.Checks, 2)
+	assert.Equal(t, StatusOK, report.Checks["cache"].Status)
+	assert.Empty(t, report.Checks["cache"].Error)
+	assert.Equal(t, StatusFail, report.Checks["queue"].Status)
+	assert.Equal(t, "connection refused", report.Checks["queue"].Error)
+}
+
+func TestHealth_CheckTimeout(t *testing.T) {
+	blocking := &testCheck{name: "slow", check: func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}}
+	h := New(blocking)
+	h.Timeout = 20 * time.Millisecond
+
+	report := h.Check(context.Background())
+	assert.Equal(t, StatusFail, report.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["slow"].Error)
+	assert.Less(t, report.Checks["slow"].LatencyMs, float64(time.Second.Milliseconds()))
+}
+
+func TestHealth_CheckPanic(t *testing.T) {
+	panicking := &testCheck{name: "broken", check: func(context.Context) error {
+		panic("boom")
+	}}
+
+	report := New(panicking).Check(context.Background())
+	assert.Equal(t, StatusFail, report.Status)
+	assert.Equal(t, "panic: boom", report.Checks["broken"].Error)
+}
+
+func TestHealth_HealthHandler(t *testing.T) {
+	status, report := serve(t, New(passing("cache")), HealthPath)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, StatusOK, report.Status)
+	assert.Contains(t, report.Checks, "cache")
+
+	status, report = serve(t, New(failing("cache", errors.New("down"))), HealthPath)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, StatusFail, report.Status)
+}
+
+func TestHealth_ReadinessHandler(t *testing.T) {
+	h := New(passing("cache"))
+
+	status, report := serve(t, h, ReadinessPath)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, StatusOK, report.Status)
+
+	h.SetShuttingDown()
+	assert.True(t, h.ShuttingDown())
+
+	status, report = serve(t, h, ReadinessPath)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, StatusShuttingDown, report.Status)
+	assert.Empty(t, report.Checks)
+}
+
+func TestHealth_LivenessHandler(t *testing.T) {
+	h := New(failing("cache", errors.New("down")))
+	h.SetShuttingDown()
+
+	status, report := serve(t, h, LivenessPath)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, StatusOK, report.Status)
+	assert.Empty(t, report.Checks)
+}
@@ -0,0 +1,433 @@
+package logfile
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// backupTimeFormat is the timestamp added to the name of rotated files,
+// for example app-2006-01-02T15-04-05.000.log.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// compressSuffix is appended to the name of compressed backups.
+const compressSuffix = ".gz"
+
+// Options controls when the file is rotated and which backups are kept.
+// Zero values disable the corresponding rule.
+type Options struct {
+	// MaxSize is the size in bytes the file may reach before it is rotated.
+	MaxSize int64
+
+	// Interval is the time after which the file is rotated, counted from
+	// when it was opened.
+	Interval time.Duration
+
+	// MaxBackups is the number of rotated files that are kept.
+	MaxBackups int
+
+	// MaxAge is the time rotated files are kept, based on the timestamp in
+	// their name.
+	MaxAge time.Duration
+
+	// Compress gzips rotated files.
+	Compress bool
+
+	// ReopenOnSIGHUP reopens the file whenever the process receives
+	// SIGHUP, which logrotate sends after moving the file away.
+	ReopenOnSIGHUP bool
+}
+
+// Writer is an io.Writer appending to a file that is rotated by size and
+// age. While the file is renamed and a new one is opened, concurrent
+// writers keep appending to the old file, so the file may exceed MaxSize
+// by the entries written during a rotation. Compressing and removing old
+// backups happens in a background goroutine. Writer is safe for concurrent
+// use.
+type Writer struct {
+	path    string
+	options Options
+	now     func() time.Time
+	rename  func(oldpath, newpath string) error
+
+	// rotateMu serializes rotating, reopening and closing the file. It is
+	// held while the file is renamed and the new one is opened, so mu is
+	// only held to swap the file and writers are not held up.
+	rotateMu sync.Mutex
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	rotating bool
+	closed   bool
+
+	maintain chan struct{}
+	signals  chan os.Signal
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// New opens the file at path for appending, creating it and its directory
+// when needed, and starts the background maintenance of its backups.
+func New(path string, options Options) (*Writer, error) {
+	return newWriter(path, options, time.Now)
+}
+
+// newWriter creates a writer reading the current time from now.
+func newWriter(path string, options Options, now func() time.Time) (*Writer, error) {
+	w := &Writer{
+		path:     path,
+		options:  options,
+		now:      now,
+		rename:   os.Rename,
+		maintain: make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	if options.ReopenOnSIGHUP {
+		w.signals = make(chan os.Signal, 1)
+		signal.Notify(w.signals, syscall.SIGHUP)
+	}
+
+	w.wg.Add(1)
+	go w.run()
+	w.scheduleMaintenance()
+	return w, nil
+}
+
+// Write appends p to the file, rotating it first when the write would
+// exceed MaxSize or the file is older than Interval. When the rotation
+// fails, the error is written to standard error and p is appended to the
+// current file rather than lost.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	if !w.closed && !w.rotating && w.shouldRotate(int64(len(p))) {
+		w.rotating = true
+		w.mu.Unlock()
+		if err := w.rotate(); err != nil && !errors.Is(err, os.ErrClosed) {
+			w.reportError(err)
+		}
+		w.mu.Lock()
+	}
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, os.ErrClosed
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Rotate moves the current file aside as a timestamped backup and opens a
+// new one.
+func (w *Writer) Rotate() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return os.ErrClosed
+	}
+	w.rotating = true
+	w.mu.Unlock()
+	return w.rotate()
+}
+
+// Reopen closes the file and opens the file at the path again. It is meant
+// for external tools such as logrotate that move the file away and expect
+// the writer to create a new one.
+func (w *Writer) Reopen() error {
+	w.rotateMu.Lock()
+	defer w.rotateMu.Unlock()
+	return w.swap()
+}
+
+// Close closes the file and waits for the pending maintenance of backups
+// to finish.
+func (w *Writer) Close() error {
+	w.rotateMu.Lock()
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		w.rotateMu.Unlock()
+		return nil
+	}
+	w.closed = true
+	if w.signals != nil {
+		signal.Stop(w.signals)
+	}
+	err := w.file.Close()
+	w.mu.Unlock()
+	w.rotateMu.Unlock()
+
+	close(w.done)
+	w.wg.Wait()
+	return err
+}
+
+// openFile opens the file at the path for appending and returns its
+// current size.
+func (w *Writer) openFile() (*os.File, int64, error) {
+	if err := os.MkdirAll(filepath.Dir(w.path), 0o755); err != nil {
+		return nil, 0, fmt.Errorf("create log directory: %w", err)
+	}
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, 0, fmt.Errorf("open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, 0, fmt.Errorf("open log file: %w", err)
+	}
+	return file, info.Size(), nil
+}
+
+func (w *Writer) open() error {
+	file, size, err := w.openFile()
+	if err != nil {
+		return err
+	}
+	w.file = file
+	w.size = size
+	w.openedAt = w.now()
+	return nil
+}
+
+func (w *Writer) shouldRotate(size int64) bool {
+	if w.size == 0 {
+		return false
+	}
+	if w.options.MaxSize > 0 && w.size+size > w.options.MaxSize {
+		return true
+	}
+	return w.options.Interval > 0 && w.now().Sub(w.openedAt) >= w.options.Interval
+}
+
+// rotate renames the file to a backup and swaps in a new one. The caller
+// sets rotating, which is cleared once the rotation is over. Writers keep
+// appending to the renamed file until the swap, so no entry is lost.
+func (w *Writer) rotate() error {
+	w.rotateMu.Lock()
+	defer w.rotateMu.Unlock()
+	defer func() {
+		w.mu.Lock()
+		w.rotating = false
+		w.mu.Unlock()
+	}()
+
+	w.mu.Lock()
+	closed := w.closed
+	w.mu.Unlock()
+	if closed {
+		return os.ErrClosed
+	}
+
+	if err := w.rename(w.path, w.backupName()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		// Keep writing to the current file rather than losing entries.
+		return fmt.Errorf("rotate log file: %w", err)
+	}
+	if err := w.swap(); err != nil {
+		return err
+	}
+	w.scheduleMaintenance()
+	return nil
+}
+
+// swap opens the file at the path and replaces the current file with it,
+// holding mu only for the replacement. The caller holds rotateMu.
+func (w *Writer) swap() error {
+	w.mu.Lock()
+	closed := w.closed
+	w.mu.Unlock()
+	if closed {
+		return os.ErrClosed
+	}
+
+	file, size, err := w.openFile()
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	previous := w.file
+	w.file = file
+	w.size = size
+	w.openedAt = w.now()
+	w.mu.Unlock()
+
+	return previous.Close()
+}
+
+// scheduleMaintenance wakes up the background goroutine without waiting
+// for it. Requests made while it is busy are coalesced into one run.
+func (w *Writer) scheduleMaintenance() {
+	select {
+	case w.maintain <- struct{}{}:
+	default:
+	}
+}
+
+func (w *Writer) run() {
+	defer w.wg.Done()
+	for {
+		select {
+		case <-w.maintain:
+			w.maintainBackups()
+		case <-w.signals:
+			if err := w.Reopen(); err != nil && !errors.Is(err, os.ErrClosed) {
+				w.reportError(err)
+			}
+		case <-w.done:
+			select {
+			case <-w.maintain:
+				w.maintainBackups()
+			default:
+			}
+			return
+		}
+	}
+}
+
+// backupName returns an unused name for the current file, stamped with the
+// current time in UTC. The time is moved forward when files are rotated
+// more than once per millisecond.
+func (w *Writer) backupName() string {
+	dir, prefix, ext := w.nameParts()
+	for t := w.now().UTC(); ; t = t.Add(time.Millisecond) {
+		name := filepath.Join(dir, prefix+t.Format(backupTimeFormat)+ext)
+		if _, err := os.Stat(name); errors.Is(err, os.ErrNotExist) {
+			if _, err := os.Stat(name + compressSuffix); errors.Is(err, os.ErrNotExist) {
+				return name
+			}
+		}
+	}
+}
+
+func (w *Writer) nameParts() (dir, prefix, ext string) {
+	dir = filepath.Dir(w.path)
+	base := filepath.Base(w.path)
+	ext = filepath.Ext(base)
+	return dir, strings.TrimSuffix(base, ext) + "-", ext
+}
+
+type backup struct {
+	path      string
+	timestamp time.Time
+}
+
+// backups returns the rotated files of the writer, newest first.
+func (w *Writer) backups() ([]backup, error) {
+	dir, prefix, ext := w.nameParts()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []backup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		stamp := strings.TrimPrefix(strings.TrimSuffix(name, compressSuffix), prefix)
+		if !strings.HasSuffix(stamp, ext) {
+			continue
+		}
+		// Backup names are stamped in UTC, which time.Parse assumes.
+		timestamp, err := time.Parse(backupTimeFormat, strings.TrimSuffix(stamp, ext))
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{path: filepath.Join(dir, name), timestamp: timestamp})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].timestamp.After(backups[j].timestamp)
+	})
+	return backups, nil
+}
+
+// maintainBackups removes the backups exceeding MaxBackups or MaxAge and
+// compresses the remaining ones. Errors are written to standard error, as
+// the log file itself may be the cause.
+func (w *Writer) maintainBackups() {
+	backups, err := w.backups()
+	if err != nil {
+		w.reportError(err)
+		return
+	}
+
+	cutoff := time.Time{}
+	if w.options.MaxAge > 0 {
+		cutoff = w.now().Add(-w.options.MaxAge)
+	}
+	for i, b := range backups {
+		expired := (w.options.MaxBackups > 0 && i >= w.options.MaxBackups) ||
+			(!cutoff.IsZero() && b.timestamp.Before(cutoff))
+		if expired {
+			if err := os.Remove(b.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				w.reportError(err)
+			}
+			continue
+		}
+		if w.options.Compress && !strings.HasSuffix(b.path, compressSuffix) {
+			if err := compress(b.path); err != nil {
+				w.reportError(err)
+			}
+		}
+	}
+}
+
+func (w *Writer) reportError(err error) {
+	_, _ = fmt.Fprintf(os.Stderr, "logfile: %s: %v\n", w.path, err)
+}
+
+// compress gzips the file next to it and removes the original. The archive
+// is written under a temporary name first, so a partial archive is never
+// mistaken for a backup.
+func compress(path string) (err error) {
+	source, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = source.Close() }()
+
+	tmp := path + compressSuffix + ".tmp"
+	target, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp)
+		}
+	}()
+
+	archive := gzip.NewWriter(target)
+	if _, err = io.Copy(archive, source); err != nil {
+		_ = target.Close()
+		return err
+	}
+	if err = archive.Close(); err != nil {
+		_ = target.Close()
+		return err
+	}
+	if err = target.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, path+compressSuffix); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
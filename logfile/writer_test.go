@@ -0,0 +1,265 @@
+package logfile
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a manually advanced clock for the writer.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestWriter(t *testing.T, options Options) (*Writer, *fakeClock, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "logs", "app.log")
+	clock := &fakeClock{now: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+	w, err := newWriter(path, options, clock.Now)
+	require.NoError(t, err)
+	return w, clock, path
+}
+
+func listDir(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestWriter_RotatesBySize(t *testing.T) {
+	w, clock, path := newTestWriter(t, Options{MaxSize: 10})
+
+	_, err := w.Write([]byte("first\n"))
+	require.NoError(t, err)
+	clock.Advance(time.Second)
+	_, err = w.Write([]byte("second\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, []string{"app-2026-01-02T03-04-06.000.log", "app.log"}, listDir(t, filepath.Dir(path)))
+	assert.Equal(t, "first\n", readFile(t, filepath.Join(filepath.Dir(path), "app-2026-01-02T03-04-06.000.log")))
+	assert.Equal(t, "second\n", readFile(t, path))
+}
+
+func TestWriter_KeepsWritingWhenRotationFails(t *testing.T) {
+	w, clock, path := newTestWriter(t, Options{MaxSize: 10})
+	w.rename = func(string, string) error {
+		return &os.LinkError{Op: "rename", Err: syscall.EBUSY}
+	}
+
+	_, err := w.Write([]byte("first\n"))
+	require.NoError(t, err)
+	clock.Advance(time.Second)
+	n, err := w.Write([]byte("second\n"))
+	require.NoError(t, err)
+	assert.Equal(t, len("second\n"), n)
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, []string{"app.log"}, listDir(t, filepath.Dir(path)))
+	assert.Equal(t, "first\nsecond\n", readFile(t, path))
+}
+
+func TestWriter_RotatesByInterval(t *testing.T) {
+	w, clock, path := newTestWriter(t, Options{Interval: time.Hour})
+
+	_, err := w.Write([]byte("first\n"))
+	require.NoError(t, err)
+	clock.Advance(30 * time.Minute)
+	_, err = w.Write([]byte("second\n"))
+	require.NoError(t, err)
+	clock.Advance(30 * time.Minute)
+	_, err = w.Write([]byte("third\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	assert.Len(t, listDir(t, filepath.Dir(path)), 2)
+	assert.Equal(t, "third\n", readFile(t, path))
+}
+
+func TestWriter_MaxBackupsAndCompression(t *testing.T) {
+	w, clock, path := newTestWriter(t, Options{MaxBackups: 2, Compress: true})
+
+	for _, line := range []string{"one\n", "two\n", "three\n"} {
+		_, err := w.Write([]byte(line))
+		require.NoError(t, err)
+		clock.Advance(time.Second)
+		require.NoError(t, w.Rotate())
+	}
+	require.NoError(t, w.Close())
+
+	dir := filepath.Dir(path)
+	assert.Equal(t, []string{
+		"app-2026-01-02T03-04-07.000.log.gz",
+		"app-2026-01-02T03-04-08.000.log.gz",
+		"app.log",
+	}, listDir(t, dir))
+
+	file, err := os.Open(filepath.Join(dir, "app-2026-01-02T03-04-08.000.log.gz"))
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+	archive, err := gzip.NewReader(file)
+	require.NoError(t, err)
+	content, err := io.ReadAll(archive)
+	require.NoError(t, err)
+	assert.Equal(t, "three\n", string(content))
+}
+
+func TestWriter_MaxAge(t *testing.T) {
+	w, clock, path := newTestWriter(t, Options{MaxAge: 24 * time.Hour})
+
+	_, err := w.Write([]byte("old\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Rotate())
+	clock.Advance(48 * time.Hour)
+	_, err = w.Write([]byte("recent\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Rotate())
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, []string{"app-2026-01-04T03-04-05.000.log", "app.log"}, listDir(t, filepath.Dir(path)))
+}
+
+func TestWriter_MaxAgeOutsideUTC(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	newYork := time.FixedZone("EST", -5*60*60)
+	clock := &fakeClock{now: time.Date(2026, 1, 2, 3, 4, 5, 0, newYork)}
+	w, err := newWriter(path, Options{MaxAge: time.Hour}, clock.Now)
+	require.NoError(t, err)
+
+	for _, line := range []string{"one\n", "two\n"} {
+		_, err := w.Write([]byte(line))
+		require.NoError(t, err)
+		clock.Advance(time.Second)
+		require.NoError(t, w.Rotate())
+	}
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, []string{
+		"app-2026-01-02T08-04-06.000.log",
+		"app-2026-01-02T08-04-07.000.log",
+		"app.log",
+	}, listDir(t, filepath.Dir(path)))
+}
+
+func TestWriter_WritesDuringRotation(t *testing.T) {
+	w, _, path := newTestWriter(t, Options{MaxSize: 1})
+	defer func() { _ = w.Close() }()
+	_, err := w.Write([]byte("first\n"))
+	require.NoError(t, err)
+
+	// Simulate a rotation renaming the file and opening the new one.
+	w.mu.Lock()
+	w.rotating = true
+	w.mu.Unlock()
+	w.rotateMu.Lock()
+
+	written := make(chan error, 1)
+	go func() {
+		_, err := w.Write([]byte("during rotation\n"))
+		written <- err
+	}()
+	select {
+	case err := <-written:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("write blocked by the rotation")
+	}
+	w.rotateMu.Unlock()
+
+	assert.Equal(t, "first\nduring rotation\n", readFile(t, path))
+}
+
+func TestWriter_Reopen(t *testing.T) {
+	w, _, path := newTestWriter(t, Options{})
+
+	_, err := w.Write([]byte("before\n"))
+	require.NoError(t, err)
+	require.NoError(t, os.Rename(path, path+".1"))
+	require.NoError(t, w.Reopen())
+	_, err = w.Write([]byte("after\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, "before\n", readFile(t, path+".1"))
+	assert.Equal(t, "after\n", readFile(t, path))
+}
+
+func TestWriter_ReopenOnSIGHUP(t *testing.T) {
+	w, _, path := newTestWriter(t, Options{ReopenOnSIGHUP: true})
+	defer func() { _ = w.Close() }()
+
+	require.NoError(t, os.Rename(path, path+".1"))
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWriter_ConcurrentWrites(t *testing.T) {
+	w, _, path := newTestWriter(t, Options{MaxSize: 64, MaxBackups: 3, Compress: true})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_, err := w.Write([]byte("concurrent entry\n"))
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, w.Close())
+
+	assert.LessOrEqual(t, len(listDir(t, filepath.Dir(path))), 4)
+}
+
+func TestWriter_Closed(t *testing.T) {
+	w, _, _ := newTestWriter(t, Options{})
+	require.NoError(t, w.Close())
+	require.NoError(t, w.Close())
+
+	_, err := w.Write([]byte("late\n"))
+	assert.ErrorIs(t, err, os.ErrClosed)
+	assert.ErrorIs(t, w.Rotate(), os.ErrClosed)
+	assert.ErrorIs(t, w.Reopen(), os.ErrClosed)
+}
@@ -1,18 +1,199 @@
 package ponodo
 
-import "github.com/rs/zerolog"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/zerpto/ponodo/config"
+	"github.com/zerpto/ponodo/contracts"
+	"github.com/zerpto/ponodo/logfile"
+	"github.com/zerpto/ponodo/tracing"
+)
 
 // Logger represents the application logger instance.
-// It wraps the zerolog logger and provides structured logging capabilities
-// for the application.
+// It wraps the configured zerolog logger, so every zerolog method is
+// available on it, and owns the files it writes to.
 type Logger struct {
+	zerolog.Logger
+
+	closers []io.Closer
 }
 
-// NewLogger creates and initializes a new logger instance.
-// It configures the zerolog time format to use Unix timestamps and
-// returns a ready-to-use logger instance.
+// NewLogger creates a logger writing JSON entries of the info level and
+// above to standard error. It configures the zerolog time format to use
+// Unix timestamps.
 func NewLogger() *Logger {
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 
-	return &Logger{}
+	return &Logger{
+		Logger: newZerolog(os.Stderr, zerolog.InfoLevel),
+	}
+}
+
+// NewLoggerFromConfig creates a logger with the level, format, outputs,
+// caller info and sampling described by the configuration. The console
+// format only applies to standard error; files always receive JSON so that
+// they stay machine readable. The file is rotated as configured and
+// reopened on SIGHUP so external tools such as logrotate can move it.
+// Close releases the files opened by the logger.
+func NewLoggerFromConfig(logConfig *config.LogConfig) (*Logger, error) {
+	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
+
+	level, err := zerolog.ParseLevel(logConfig.Level)
+	if err != nil {
+		return nil, fmt.Errorf("%w: LOG_LEVEL: %w", config.ErrConfigInvalid, err)
+	}
+
+	logger := &Logger{}
+	var writers []io.Writer
+	if logConfig.WritesStderr() {
+		var stderr io.Writer = os.Stderr
+		if logConfig.Format == config.LogFormatConsole {
+			stderr = zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339}
+		}
+		writers = append(writers, stderr)
+	}
+	if logConfig.WritesFile() {
+		file, err := logfile.New(logConfig.File, logfile.Options{
+			MaxSize:        int64(logConfig.MaxSize) * 1024 * 1024,
+			Interval:       logConfig.RotateInterval,
+			MaxBackups:     logConfig.MaxBackups,
+			MaxAge:         logConfig.MaxAge,
+			Compress:       logConfig.Compress,
+			ReopenOnSIGHUP: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("open log file: %w", err)
+		}
+		logger.closers = append(logger.closers, file)
+		writers = append(writers, file)
+	}
+
+	logger.Logger = newZerolog(zerolog.MultiLevelWriter(writers...), level)
+	if logConfig.Caller {
+		logger.Logger = logger.With().Caller().Logger()
+	}
+	if logConfig.SampleRate > 1 {
+		sampler := &zerolog.BasicSampler{N: logConfig.SampleRate}
+		logger.Logger = logger.Sample(zerolog.LevelSampler{
+			TraceSampler: sampler,
+			DebugSampler: sampler,
+			InfoSampler:  sampler,
+		})
+	}
+	return logger, nil
+}
+
+// newZerolog creates a timestamped logger that adds the trace and span IDs
+// of the span carried by the context of an entry.
+func newZerolog(w io.Writer, level zerolog.Level) zerolog.Logger {
+	return zerolog.New(w).
+		Level(level).
+		With().Timestamp().Logger().
+		Hook(tracing.LogHook{})
+}
+
+// Close closes the files the logger writes to.
+func (l *Logger) Close() error {
+	var errs []error
+	for _, closer := range l.closers {
+		errs = append(errs, closer.Close())
+	}
+	l.closers = nil
+	return errors.Join(errs...)
+}
+
+// LoggerServiceProvider is the built-in service provider that configures
+// the application logger. It is registered by NewApp under the name
+// "logger" and can be replaced or removed like any other provider.
+type LoggerServiceProvider struct {
+	logger *Logger
+}
+
+// Name returns the name the logger provider is registered under.
+func (p *LoggerServiceProvider) Name() string {
+	return "logger"
+}
+
+// DependsOn returns the providers that must be registered before the logger.
+func (p *LoggerServiceProvider) DependsOn() []string {
+	return nil
+}
+
+// Register creates the logger from the LOG_* configuration, or the default
+// logger when no configuration has been loaded. It stores the logger on the
+// application, binds it into the application container and installs it as
+// the global zerolog logger used by the framework.
+func (p *LoggerServiceProvider) Register(app contracts.AppContract) error {
+	logger := NewLogger()
+	if loader := app.GetConfigLoader(); loader != nil {
+		logConfig, err := loader.GetLogConfig()
+		if err != nil {
+			return err
+		}
+		if logger, err = NewLoggerFromConfig(logConfig); err != nil {
+			return err
+		}
+	}
+
+	p.logger = logger
+	log.Logger = logger.Logger
+	app.SetLogger(&logger.Logger)
+	Instance(app, logger)
+	return nil
+}
+
+// Boot is a no-op for the logger provider.
+func (p *LoggerServiceProvider) Boot(app contracts.AppContract) error {
+	return nil
+}
+
+// Shutdown closes the log files.
+func (p *LoggerServiceProvider) Shutdown(app contracts.AppContract) error {
+	if p.logger == nil {
+		return nil
+	}
+	return p.logger.Close()
+}
+
+// NewLoggerServiceProvider creates the built-in logger service provider.
+func NewLoggerServiceProvider() contracts.ServiceProviderContract {
+	return &LoggerServiceProvider{}
+}
+
+// LoggerFromContext returns the request-scoped logger attached by the
+// access log middleware, which carries the request ID of the request. It
+// accepts either the *gin.Context of the request or its request context,
+// so handlers and the code they call, such as GORM queries run with
+// db.WithContext(ctx), log with the same request ID. It falls back to the
+// global logger when no logger is attached.
+func LoggerFromContext(ctx context.Context) *zerolog.Logger {
+	if logger := zerolog.Ctx(requestContext(ctx)); logger.GetLevel() != zerolog.Disabled {
+		return logger
+	}
+	return &log.Logger
+}
+
+// requestContext returns the request context of a *gin.Context, whose own
+// Value method does not reach it, and the context itself otherwise. A nil
+// context is replaced by the background context.
+func requestContext(ctx context.Context) context.Context {
+	if ginCtx, ok := ctx.(*gin.Context); ok {
+		if ginCtx.Request == nil {
+			return context.Background()
+		}
+		return ginCtx.Request.Context()
+	}
+	if ctx == nil {
+		return context.Background()
+	}
+	return ctx
 }
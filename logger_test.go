@@ -1,7 +1,23 @@
 package ponodo
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zerpto/ponodo/config"
 )
 
 func TestNewLogger(t *testing.T) {
@@ -16,3 +32,155 @@ func TestNewLogger(t *testing.T) {
 		t.Error("NewLogger did not return a *Logger instance")
 	}
 }
+
+func readLogEntries(t *testing.T, file string) []map[string]any {
+	t.Helper()
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestNewLoggerFromConfig_File(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "app.log")
+	logger, err := NewLoggerFromConfig(&config.LogConfig{
+		Level:  "warn",
+		Format: config.LogFormatConsole,
+		Output: config.LogOutputFile,
+		File:   file,
+		Caller: true,
+	})
+	require.NoError(t, err)
+
+	logger.Info().Msg("skipped")
+	logger.Warn().Str("order", "42").Msg("slow order")
+	require.NoError(t, logger.Close())
+
+	entries := readLogEntries(t, file)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "warn", entries[0]["level"])
+	assert.Equal(t, "slow order", entries[0]["message"])
+	assert.Equal(t, "42", entries[0]["order"])
+	assert.Contains(t, entries[0]["caller"], "logger_test.go")
+	assert.Contains(t, entries[0], "time")
+}
+
+func TestNewLoggerFromConfig_Sampling(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "app.log")
+	logger, err := NewLoggerFromConfig(&config.LogConfig{
+		Level:      "info",
+		Format:     config.LogFormatJSON,
+		Output:     config.LogOutputFile,
+		File:       file,
+		SampleRate: 5,
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		logger.Info().Msg("request served")
+		logger.Error().Msg("request failed")
+	}
+	require.NoError(t, logger.Close())
+
+	levels := map[string]int{}
+	for _, entry := range readLogEntries(t, file) {
+		levels[entry["level"].(string)]++
+	}
+	assert.Equal(t, 2, levels["info"])
+	assert.Equal(t, 10, levels["error"])
+}
+
+func TestNewLoggerFromConfig_InvalidLevel(t *testing.T) {
+	_, err := NewLoggerFromConfig(&config.LogConfig{Level: "verbose", Output: config.LogOutputStderr})
+	assert.ErrorIs(t, err, config.ErrConfigInvalid)
+}
+
+func TestNewLoggerFromConfig_UnwritableFile(t *testing.T) {
+	parent := filepath.Join(t.TempDir(), "not-a-directory")
+	require.NoError(t, os.WriteFile(parent, nil, 0o644))
+
+	_, err := NewLoggerFromConfig(&config.LogConfig{
+		Level:  "info",
+		Output: config.LogOutputFile,
+		File:   filepath.Join(parent, "app.log"),
+	})
+	assert.Error(t, err)
+}
+
+func TestNewLoggerFromConfig_Rotation(t *testing.T) {
+	dir := t.TempDir()
+	logger, err := NewLoggerFromConfig(&config.LogConfig{
+		Level:      "info",
+		Output:     config.LogOutputFile,
+		File:       filepath.Join(dir, "app.log"),
+		MaxSize:    1,
+		MaxBackups: 1,
+	})
+	require.NoError(t, err)
+
+	payload := strings.Repeat("x", 64*1024)
+	for i := 0; i < 40; i++ {
+		logger.Info().Str("payload", payload).Msg("large entry")
+	}
+	require.NoError(t, logger.Close())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+	info, err := os.Stat(filepath.Join(dir, "app.log"))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, info.Size(), int64(1024*1024))
+}
+
+func TestLoggerServiceProvider(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	file := filepath.Join(t.TempDir(), "app.log")
+	viper.Set("log_output", "file")
+	viper.Set("log_file", file)
+	viper.Set("log_level", "debug")
+
+	previous := log.Logger
+	defer func() { log.Logger = previous }()
+
+	app := &App{ConfigLoader: &config.Loader{}}
+	provider := NewLoggerServiceProvider()
+	require.NoError(t, provider.Register(app))
+
+	logger, err := Make[*Logger](app)
+	require.NoError(t, err)
+	assert.Same(t, &logger.Logger, app.GetLogger())
+	assert.Equal(t, zerolog.DebugLevel, log.Logger.GetLevel())
+
+	log.Debug().Msg("through the global logger")
+	require.NoError(t, provider.Shutdown(app))
+
+	entries := readLogEntries(t, file)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "through the global logger", entries[0]["message"])
+}
+
+func TestApp_GetLogger_Default(t *testing.T) {
+	app := &App{}
+	assert.Same(t, &log.Logger, app.GetLogger())
+}
+
+func TestLoggerFromContext(t *testing.T) {
+	logger := zerolog.Nop().With().Str("request_id", "req-123").Logger().Level(zerolog.InfoLevel)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(logger.WithContext(req.Context()))
+
+	assert.Equal(t, &logger, LoggerFromContext(req.Context()))
+	assert.Equal(t, &logger, LoggerFromContext(&gin.Context{Request: req}))
+	assert.Same(t, &log.Logger, LoggerFromContext(context.Background()))
+	assert.Same(t, &log.Logger, LoggerFromContext(&gin.Context{}))
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestDBStatsCollector(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "stats.db")), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(4)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(NewDBStatsCollector(func() map[string]*gorm.DB {
+		return map[string]*gorm.DB{"default": db}
+	}))
+
+	expected := `
+# HELP db_pool_max_open_connections Maximum number of open connections to the database.
+# TYPE db_pool_max_open_connections gauge
+db_pool_max_open_connections{connection="default"} 4
+# HELP db_pool_in_use_connections Number of connections currently in use.
+# TYPE db_pool_in_use_connections gauge
+db_pool_in_use_connections{connection="default"} 0
+`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"db_pool_max_open_connections", "db_pool_in_use_connections"))
+
+	problems, err := testutil.CollectAndLint(NewDBStatsCollector(func() map[string]*gorm.DB {
+		return map[string]*gorm.DB{"default": db}
+	}))
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
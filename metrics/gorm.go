@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+// GormPluginName is the name GormPlugin is registered under on a GORM
+// connection.
+const GormPluginName = "ponodo:metrics"
+
+const startKey = "ponodo:metrics:start"
+
+var (
+	queryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Duration of database queries in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"connection", "operation"})
+
+	queryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_query_errors_total",
+		Help: "Total number of failed database queries.",
+	}, []string{"connection", "operation"})
+)
+
+// GormPlugin records the duration and the errors of the queries run on a
+// GORM connection, labeled with the connection name and the operation:
+// create, query, update, delete, row or raw. The connection label matches
+// the one of the pool statistics exported by DBStatsCollector. Record not
+// found errors are not counted as failures. The collectors are registered
+// by NewRegistry.
+type GormPlugin struct {
+	Connection string
+}
+
+// NewGormPlugin creates the metrics plugin for the named connection.
+func NewGormPlugin(connection string) *GormPlugin {
+	return &GormPlugin{
+		Connection: connection,
+	}
+}
+
+// Name returns the name of the plugin.
+func (p *GormPlugin) Name() string {
+	return GormPluginName
+}
+
+// Initialize registers the plugin callbacks around every GORM operation.
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("gorm:create").Register(GormPluginName+":before_create", p.before),
+		callbacks.Create().After("gorm:create").Register(GormPluginName+":after_create", p.after("create")),
+		callbacks.Query().Before("gorm:query").Register(GormPluginName+":before_query", p.before),
+		callbacks.Query().After("gorm:query").Register(GormPluginName+":after_query", p.after("query")),
+		callbacks.Update().Before("gorm:update").Register(GormPluginName+":before_update", p.before),
+		callbacks.Update().After("gorm:update").Register(GormPluginName+":after_update", p.after("update")),
+		callbacks.Delete().Before("gorm:delete").Register(GormPluginName+":before_delete", p.before),
+		callbacks.Delete().After("gorm:delete").Register(GormPluginName+":after_delete", p.after("delete")),
+		callbacks.Row().Before("gorm:row").Register(GormPluginName+":before_row", p.before),
+		callbacks.Row().After("gorm:row").Register(GormPluginName+":after_row", p.after("row")),
+		callbacks.Raw().Before("gorm:raw").Register(GormPluginName+":before_raw", p.before),
+		callbacks.Raw().After("gorm:raw").Register(GormPluginName+":after_raw", p.after("raw")),
+	)
+}
+
+func (p *GormPlugin) before(db *gorm.DB) {
+	db.InstanceSet(startKey, time.Now())
+}
+
+func (p *GormPlugin) after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		queryDuration.WithLabelValues(p.Connection, operation).Observe(time.Since(start).Seconds())
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			queryErrors.WithLabelValues(p.Connection, operation).Inc()
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type metricsTestRecord struct {
+	ID   uint
+	Name string
+}
+
+func TestGormPlugin(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "metrics.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(NewGormPlugin("gorm_plugin_test")))
+	require.NoError(t, db.AutoMigrate(&metricsTestRecord{}))
+
+	require.NoError(t, db.Create(&metricsTestRecord{Name: "first"}).Error)
+	var record metricsTestRecord
+	require.NoError(t, db.First(&record).Error)
+	assert.ErrorIs(t, db.First(&record, 42).Error, gorm.ErrRecordNotFound)
+	assert.Error(t, db.Exec("SELECT * FROM missing_table").Error)
+
+	registry := NewRegistry()
+	count, err := testutil.GatherAndCount(registry, "db_query_duration_seconds")
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, count, 3)
+
+	assert.Equal(t, float64(0), testutil.ToFloat64(queryErrors.WithLabelValues("gorm_plugin_test", "query")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(queryErrors.WithLabelValues("gorm_plugin_test", "raw")))
+	assert.Equal(t, GormPluginName, NewGormPlugin("gorm_plugin_test").Name())
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// UnmatchedRoute is the route label of requests that match no route, so
+// that unknown paths cannot blow up the number of series.
+const UnmatchedRoute = "unmatched"
+
+// Middleware records the number, the latency and the number of in-flight
+// HTTP requests. Requests are labeled with the method, the route template
+// such as /users/:id and the response status. The collectors are
+// registered on the registerer.
+func Middleware(registerer prometheus.Registerer) gin.HandlerFunc {
+	labels := []string{"method", "route", "status"}
+	requests := register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total number of HTTP requests.",
+	}, labels))
+	duration := register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Duration of HTTP requests in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, labels))
+	inFlight := register(registerer, prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "Number of HTTP requests currently being served.",
+	}))
+
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		inFlight.Inc()
+		defer inFlight.Dec()
+
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = UnmatchedRoute
+		}
+		status := strconv.Itoa(ctx.Writer.Status())
+		requests.WithLabelValues(ctx.Request.Method, route, status).Inc()
+		duration.WithLabelValues(ctx.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	registry := prometheus.NewRegistry()
+
+	r := gin.New()
+	r.Use(Middleware(registry))
+	r.GET("/users/:id", func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	for _, path := range []string{"/users/1", "/users/2", "/missing"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	expected := `
+# HELP http_requests_total Total number of HTTP requests.
+# TYPE http_requests_total counter
+http_requests_total{method="GET",route="/users/:id",status="200"} 2
+http_requests_total{method="GET",route="unmatched",status="404"} 1
+# HELP http_requests_in_flight Number of HTTP requests currently being served.
+# TYPE http_requests_in_flight gauge
+http_requests_in_flight 0
+`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"http_requests_total", "http_requests_in_flight"))
+
+	count, err := testutil.GatherAndCount(registry, "http_request_duration_seconds")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestMiddleware_InFlight(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	registry := prometheus.NewRegistry()
+
+	var inFlight float64
+	r := gin.New()
+	r.Use(Middleware(registry))
+	r.GET("/", func(ctx *gin.Context) {
+		families, err := registry.Gather()
+		require.NoError(t, err)
+		for _, family := range families {
+			if family.GetName() == "http_requests_in_flight" {
+				inFlight = family.GetMetric()[0].GetGauge().GetValue()
+			}
+		}
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, float64(1), inFlight)
+}
+
+func TestMiddleware_SameRegistryTwice(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	assert.NotPanics(t, func() {
+		Middleware(registry)
+		Middleware(registry)
+	})
+}
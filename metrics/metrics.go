@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Path is the path the metrics endpoint is mounted on.
+const Path = "/metrics"
+
+// NewRegistry creates a registry holding the Go runtime and process
+// collectors together with the database query collectors fed by
+// GormPlugin.
+func NewRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		queryDuration,
+		queryErrors,
+	)
+	return registry
+}
+
+// Handler serves the metrics gathered by the registry. The Prometheus text
+// format is used unless the scraper negotiates another one.
+func Handler(gatherer prometheus.Gatherer) gin.HandlerFunc {
+	handler := promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})
+	return gin.WrapH(handler)
+}
+
+// register registers the collector, or returns the equivalent collector
+// that is already registered, so that building the middleware twice on the
+// same registry does not fail. It panics on any other registration error,
+// like prometheus.MustRegister.
+func register[T prometheus.Collector](registerer prometheus.Registerer, collector T) T {
+	err := registerer.Register(collector)
+	if err == nil {
+		return collector
+	}
+
+	var already prometheus.AlreadyRegisteredError
+	if errors.As(err, &already) {
+		if existing, ok := already.ExistingCollector.(T); ok {
+			return existing
+		}
+	}
+	panic(err)
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	registry := NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "orders_total", Help: "Total orders."})
+	registry.MustRegister(counter)
+	counter.Add(3)
+
+	r := gin.New()
+	r.GET(Path, Handler(registry))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, Path, nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/plain")
+	assert.Contains(t, w.Body.String(), "orders_total 3")
+	assert.Contains(t, w.Body.String(), "go_goroutines")
+}
+
+func TestRegister_ReusesExistingCollector(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	opts := prometheus.CounterOpts{Name: "jobs_total", Help: "Total jobs."}
+
+	first := register(registry, prometheus.NewCounter(opts))
+	second := register(registry, prometheus.NewCounter(opts))
+	assert.Same(t, first, second)
+
+	assert.Panics(t, func() {
+		register(registry, prometheus.NewGauge(prometheus.GaugeOpts{Name: "jobs_total", Help: "Other help."}))
+	})
+}
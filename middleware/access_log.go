@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// AccessLog returns a middleware that attaches a child of the given logger
+// to the request context and writes one access log entry per request.
+// The child logger carries the request ID assigned by RequestID, and the
+// request context so that trace and span IDs are added when tracing is
+// enabled. Handlers retrieve it with zerolog.Ctx or ponodo.LoggerFromContext.
+//
+// The entry holds the method, route template, path, status, latency,
+// response size, client IP and user agent. Server errors are logged at the
+// error level, client errors at the warn level and everything else at the
+// info level.
+func AccessLog(logger *zerolog.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		requestLogger := logger.With().
+			Ctx(ctx.Request.Context()).
+			Str("request_id", GetRequestID(ctx)).
+			Logger()
+		ctx.Request = ctx.Request.WithContext(requestLogger.WithContext(ctx.Request.Context()))
+
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		event := requestLogger.Info()
+		switch {
+		case status >= http.StatusInternalServerError:
+			event = requestLogger.Error()
+		case status >= http.StatusBadRequest:
+			event = requestLogger.Warn()
+		}
+
+		event.
+			Str("method", ctx.Request.Method).
+			Str("route", ctx.FullPath()).
+			Str("path", ctx.Request.URL.Path).
+			Int("status", status).
+			Dur("latency", time.Since(start)).
+			Int("bytes", max(ctx.Writer.Size(), 0)).
+			Str("client_ip", ctx.ClientIP()).
+			Str("user_agent", ctx.Request.UserAgent()).
+			Msg("request handled")
+	}
+}
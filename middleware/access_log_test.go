@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAccessLogRouter(logger *zerolog.Logger) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID(), AccessLog(logger))
+	router.GET("/users/:id", func(ctx *gin.Context) {
+		zerolog.Ctx(ctx.Request.Context()).Info().Msg("loading user")
+		ctx.String(http.StatusOK, "user")
+	})
+	router.GET("/fail", func(ctx *gin.Context) {
+		ctx.Status(http.StatusServiceUnavailable)
+	})
+	return router
+}
+
+func decodeLogLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestAccessLog(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := zerolog.New(buf)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	req.Header.Set(RequestIDHeader, "req-123")
+	req.Header.Set("User-Agent", "ponodo-test")
+	req.RemoteAddr = "192.0.2.1:1234"
+	w := httptest.NewRecorder()
+	newAccessLogRouter(&logger).ServeHTTP(w, req)
+
+	entries := decodeLogLines(t, buf)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "loading user", entries[0]["message"])
+	assert.Equal(t, "req-123", entries[0]["request_id"])
+
+	access := entries[1]
+	assert.Equal(t, "info", access["level"])
+	assert.Equal(t, "req-123", access["request_id"])
+	assert.Equal(t, "GET", access["method"])
+	assert.Equal(t, "/users/:id", access["route"])
+	assert.Equal(t, "/users/42", access["path"])
+	assert.EqualValues(t, http.StatusOK, access["status"])
+	assert.EqualValues(t, len("user"), access["bytes"])
+	assert.Equal(t, "192.0.2.1", access["client_ip"])
+	assert.Equal(t, "ponodo-test", access["user_agent"])
+	assert.Contains(t, access, "latency")
+}
+
+func TestAccessLog_Levels(t *testing.T) {
+	tests := map[string]struct {
+		path  string
+		route string
+		level string
+	}{
+		"server error": {path: "/fail", route: "/fail", level: "error"},
+		"not found":    {path: "/missing", route: "", level: "warn"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			logger := zerolog.New(buf)
+
+			w := httptest.NewRecorder()
+			newAccessLogRouter(&logger).ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			entries := decodeLogLines(t, buf)
+			require.Len(t, entries, 1)
+			assert.Equal(t, tt.level, entries[0]["level"])
+			assert.Equal(t, tt.route, entries[0]["route"])
+			assert.EqualValues(t, 0, entries[0]["bytes"])
+		})
+	}
+}
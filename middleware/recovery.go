@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"strings"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/zerpto/ponodo/apperror"
+	"github.com/zerpto/ponodo/response"
+)
+
+// Recovery returns a middleware that recovers from panics in the handlers
+// that follow it. The panic and its stack are logged together with the
+// request ID, and the client receives the standard error envelope through
+// response.InternalServerError. The stack trace is only included in the
+// response details when debug is true.
+func Recovery(debugMode bool) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				// Deliberate aborts are rethrown for net/http to handle.
+				panic(recovered)
+			}
+
+			stack := debug.Stack()
+			log.Error().
+				Ctx(ctx.Request.Context()).
+				Str("request_id", GetRequestID(ctx)).
+				Str("method", ctx.Request.Method).
+				Str("path", ctx.Request.URL.Path).
+				Interface("panic", recovered).
+				Bytes("stack", stack).
+				Msg("recovered from panic")
+
+			if brokenPipe(recovered) || ctx.Writer.Written() {
+				// The client is gone or the response has been started, so
+				// the error envelope cannot be sent anymore.
+				ctx.Abort()
+				return
+			}
+
+			err := apperror.ErrInternal.WithCause(fmt.Errorf("panic: %v", recovered))
+			if debugMode {
+				err = err.WithDetails(map[string]any{
+					"stack": strings.Split(strings.TrimSpace(string(stack)), "\n"),
+				})
+			}
+			response.InternalServerError(ctx, err)
+		}()
+
+		ctx.Next()
+	}
+}
+
+// brokenPipe reports whether the panic was caused by the client closing
+// the connection.
+func brokenPipe(recovered any) bool {
+	err, ok := recovered.(error)
+	if !ok {
+		return false
+	}
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		return false
+	}
+	var syscallErr *os.SyscallError
+	if errors.As(opErr, &syscallErr) {
+		return errors.Is(syscallErr.Err, syscall.EPIPE) || errors.Is(syscallErr.Err, syscall.ECONNRESET)
+	}
+	return false
+}
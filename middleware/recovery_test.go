@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	original := log.Logger
+	log.Logger = zerolog.New(buf)
+	t.Cleanup(func() { log.Logger = original })
+	return buf
+}
+
+func newPanickingRouter(debug bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Recovery(debug))
+	router.GET("/panic", func(ctx *gin.Context) {
+		panic("something went wrong")
+	})
+	return router
+}
+
+func TestRecovery(t *testing.T) {
+	logs := captureLog(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set(RequestIDHeader, "req-123")
+	w := httptest.NewRecorder()
+	newPanickingRouter(false).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "internal_error", body["code"])
+	assert.NotContains(t, body, "details")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.Equal(t, "req-123", entry["request_id"])
+	assert.Equal(t, "something went wrong", entry["panic"])
+	assert.Contains(t, entry["stack"], "recovery_test.go")
+}
+
+func TestRecovery_DebugIncludesStack(t *testing.T) {
+	captureLog(t)
+
+	w := httptest.NewRecorder()
+	newPanickingRouter(true).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	var body struct {
+		Details struct {
+			Stack []string `json:"stack"`
+		} `json:"details"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.NotEmpty(t, body.Details.Stack)
+}
+
+func TestRecovery_ResponseAlreadyWritten(t *testing.T) {
+	captureLog(t)
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(Recovery(true))
+	router.GET("/stream", func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, "partial")
+		panic("late failure")
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stream", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "partial", w.Body.String())
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader is the header carrying the request ID.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDKey is the gin context key under which the request ID is stored.
+const RequestIDKey = "request_id"
+
+// maxRequestIDLength is the length above which an incoming request ID is
+// replaced by a generated one.
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// RequestID returns a middleware that assigns an ID to every request. The
+// ID sent by the client or a proxy in the X-Request-ID header is kept when
+// it is a short printable string, and a random UUID is generated
+// otherwise. The ID is stored under RequestIDKey, added to the request
+// context and echoed in the response header.
+func RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+
+		ctx.Set(RequestIDKey, id)
+		ctx.Request = ctx.Request.WithContext(WithRequestID(ctx.Request.Context(), id))
+		ctx.Header(RequestIDHeader, id)
+		ctx.Next()
+	}
+}
+
+// GetRequestID returns the ID of the request. It prefers the ID stored on
+// the context under RequestIDKey and falls back to the X-Request-ID header
+// sent by the client or a proxy. It returns an empty string when neither
+// is set.
+func GetRequestID(ctx *gin.Context) string {
+	if id := ctx.GetString(RequestIDKey); id != "" {
+		return id
+	}
+	if ctx.Request == nil {
+		return ""
+	}
+	return ctx.Request.Header.Get(RequestIDHeader)
+}
+
+// WithRequestID returns a copy of the context carrying the request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored by RequestID, so code
+// below the HTTP layer that only receives the request context can read
+// it. It returns an empty string when the middleware is not installed.
+func RequestIDFromContext(ctx context.Context) string {
+	if ginCtx, ok := ctx.(*gin.Context); ok {
+		return GetRequestID(ginCtx)
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// validRequestID reports whether an incoming request ID can be trusted to
+// appear in logs and response headers as is.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if r <= 0x20 || r >= 0x7f {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRequestIDRouter(seen *string, fromContext *string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID())
+	router.GET("/id", func(ctx *gin.Context) {
+		*seen = GetRequestID(ctx)
+		*fromContext = RequestIDFromContext(ctx.Request.Context())
+		ctx.Status(http.StatusNoContent)
+	})
+	return router
+}
+
+func TestRequestID_Propagates(t *testing.T) {
+	var seen, fromContext string
+	req := httptest.NewRequest(http.MethodGet, "/id", nil)
+	req.Header.Set(RequestIDHeader, "req-123")
+	w := httptest.NewRecorder()
+	newRequestIDRouter(&seen, &fromContext).ServeHTTP(w, req)
+
+	assert.Equal(t, "req-123", seen)
+	assert.Equal(t, "req-123", fromContext)
+	assert.Equal(t, "req-123", w.Header().Get(RequestIDHeader))
+}
+
+func TestRequestID_Generates(t *testing.T) {
+	tests := map[string]string{
+		"missing":  "",
+		"too long": strings.Repeat("a", maxRequestIDLength+1),
+		"newline":  "req\n123",
+		"space":    "req 123",
+	}
+	for name, header := range tests {
+		t.Run(name, func(t *testing.T) {
+			var seen, fromContext string
+			req := httptest.NewRequest(http.MethodGet, "/id", nil)
+			if header != "" {
+				req.Header[RequestIDHeader] = []string{header}
+			}
+			w := httptest.NewRecorder()
+			newRequestIDRouter(&seen, &fromContext).ServeHTTP(w, req)
+
+			_, err := uuid.Parse(seen)
+			require.NoError(t, err)
+			assert.Equal(t, seen, fromContext)
+			assert.Equal(t, seen, w.Header().Get(RequestIDHeader))
+		})
+	}
+}
+
+func TestRequestIDFromContext_Missing(t *testing.T) {
+	assert.Empty(t, RequestIDFromContext(httptest.NewRequest(http.MethodGet, "/", nil).Context()))
+}
@@ -0,0 +1,64 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// localLock serializes migration runs within the process for databases
+// without advisory locks, such as SQLite.
+var localLock sync.Mutex
+
+// acquireLock takes a database wide advisory lock named after the tracking
+// table and returns the function releasing it. The lock is held on a
+// dedicated connection because advisory locks belong to a session.
+func acquireLock(ctx context.Context, db *gorm.DB, name string) (func(), error) {
+	var lockSQL, unlockSQL string
+	var args []any
+
+	switch db.Dialector.Name() {
+	case "postgres":
+		key := lockKey(name)
+		lockSQL, unlockSQL = "SELECT pg_advisory_lock($1)", "SELECT pg_advisory_unlock($1)"
+		args = []any{key}
+	case "mysql":
+		lockSQL, unlockSQL = "SELECT GET_LOCK(?, -1)", "SELECT RELEASE_LOCK(?)"
+		args = []any{name}
+	case "sqlserver":
+		lockSQL = "EXEC sp_getapplock @Resource = @p1, @LockMode = 'Exclusive', @LockOwner = 'Session', @LockTimeout = -1"
+		unlockSQL = "EXEC sp_releaseapplock @Resource = @p1, @LockOwner = 'Session'"
+		args = []any{name}
+	default:
+		localLock.Lock()
+		return localLock.Unlock, nil
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("migration: acquire lock connection: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, lockSQL, args...); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("migration: acquire lock: %w", err)
+	}
+
+	return func() {
+		// Release with a fresh context so a cancelled run still unlocks.
+		_, _ = conn.ExecContext(context.Background(), unlockSQL, args...)
+		_ = conn.Close()
+	}, nil
+}
+
+func lockKey(name string) int64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte("ponodo:migration:" + name))
+	return int64(hash.Sum64() >> 1)
+}
@@ -0,0 +1,333 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// DefaultTableName is the name of the table used to track applied migrations.
+const DefaultTableName = "schema_migrations"
+
+var (
+	// ErrInvalidMigration is returned when a migration has no version or
+	// defines both a Go function and raw SQL for the same direction.
+	ErrInvalidMigration = errors.New("migration: invalid migration")
+
+	// ErrDuplicateVersion is returned when two migrations share a version.
+	ErrDuplicateVersion = errors.New("migration: duplicate version")
+
+	// ErrIrreversible is returned when rolling back a migration that has
+	// no down step.
+	ErrIrreversible = errors.New("migration: migration has no down step")
+)
+
+// Migration describes a single versioned schema change. Each direction is
+// either a Go function or a raw SQL statement executed inside a transaction.
+// Versions are applied in lexical order, so timestamps such as
+// "20240101120000" are recommended.
+type Migration struct {
+	Version string
+	Name    string
+
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+	UpSQL   string
+	DownSQL string
+}
+
+// Record is a row of the migration tracking table.
+type Record struct {
+	Version   string `gorm:"primaryKey;size:191"`
+	Name      string `gorm:"size:255"`
+	Batch     int
+	AppliedAt time.Time
+}
+
+// Status reports whether a registered migration has been applied.
+type Status struct {
+	Version   string
+	Name      string
+	Applied   bool
+	Batch     int
+	AppliedAt *time.Time
+}
+
+// Migrator applies and rolls back migrations against a database. Runs are
+// guarded by an advisory lock so that concurrent deployments do not apply
+// the same migration twice.
+type Migrator struct {
+	DB         *gorm.DB
+	Migrations []Migration
+	TableName  string
+}
+
+// NewMigrator creates a migrator for the given database and migrations.
+func NewMigrator(db *gorm.DB, migrations []Migration) *Migrator {
+	return &Migrator{
+		DB:         db,
+		Migrations: migrations,
+		TableName:  DefaultTableName,
+	}
+}
+
+// Up applies every pending migration in version order as a single batch
+// and returns the migrations that were applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.run(ctx, func(db *gorm.DB) error {
+		var err error
+		applied, err = m.up(db)
+		return err
+	})
+	return applied, err
+}
+
+// Down rolls back the given number of most recently applied migrations and
+// returns the migrations that were rolled back. A steps value below one
+// rolls back a single migration.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps < 1 {
+		steps = 1
+	}
+
+	var rolledBack []Migration
+	err := m.run(ctx, func(db *gorm.DB) error {
+		records, err := m.records(db)
+		if err != nil {
+			return err
+		}
+
+		sorted := m.sorted()
+		for i := len(sorted) - 1; i >= 0 && len(rolledBack) < steps; i-- {
+			migration := sorted[i]
+			if _, ok := records[migration.Version]; !ok {
+				continue
+			}
+			if err := m.rollback(db, migration); err != nil {
+				return err
+			}
+			rolledBack = append(rolledBack, migration)
+		}
+		return nil
+	})
+	return rolledBack, err
+}
+
+// Status returns the state of every registered migration in version order.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+
+	db := m.session(ctx)
+	if err := m.ensureTable(db); err != nil {
+		return nil, err
+	}
+	records, err := m.records(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.Migrations))
+	for _, migration := range m.sorted() {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if record, ok := records[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.Applied = true
+			status.Batch = record.Batch
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Fresh drops every table in the database, including the tracking table,
+// and applies all migrations from scratch.
+func (m *Migrator) Fresh(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.run(ctx, func(db *gorm.DB) error {
+		tables, err := db.Migrator().GetTables()
+		if err != nil {
+			return err
+		}
+		for _, table := range tables {
+			if strings.HasPrefix(table, "sqlite_") {
+				// SQLite internal tables cannot be dropped.
+				continue
+			}
+			if err := db.Migrator().DropTable(table); err != nil {
+				return fmt.Errorf("drop table %s: %w", table, err)
+			}
+		}
+
+		if err := m.ensureTable(db); err != nil {
+			return err
+		}
+		applied, err = m.up(db)
+		return err
+	})
+	return applied, err
+}
+
+func (m *Migrator) run(ctx context.Context, fn func(db *gorm.DB) error) error {
+	if err := m.validate(); err != nil {
+		return err
+	}
+
+	db := m.session(ctx)
+	unlock, err := acquireLock(ctx, db, m.tableName())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := m.ensureTable(db); err != nil {
+		return err
+	}
+	return fn(db)
+}
+
+func (m *Migrator) up(db *gorm.DB) ([]Migration, error) {
+	records, err := m.records(db)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := 1
+	for _, record := range records {
+		if record.Batch >= batch {
+			batch = record.Batch + 1
+		}
+	}
+
+	var applied []Migration
+	for _, migration := range m.sorted() {
+		if _, ok := records[migration.Version]; ok {
+			continue
+		}
+		if err := m.apply(db, migration, batch); err != nil {
+			return applied, err
+		}
+		applied = append(applied, migration)
+	}
+	return applied, nil
+}
+
+func (m *Migrator) apply(db *gorm.DB, migration Migration, batch int) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := step(tx, migration.Up, migration.UpSQL); err != nil {
+			return err
+		}
+		return tx.Table(m.tableName()).Create(&Record{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Batch:     batch,
+			AppliedAt: time.Now(),
+		}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("apply %s: %w", describe(migration), err)
+	}
+	return nil
+}
+
+func (m *Migrator) rollback(db *gorm.DB, migration Migration) error {
+	if migration.Down == nil && migration.DownSQL == "" {
+		return fmt.Errorf("%w: %s", ErrIrreversible, describe(migration))
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := step(tx, migration.Down, migration.DownSQL); err != nil {
+			return err
+		}
+		return tx.Table(m.tableName()).Where("version = ?", migration.Version).Delete(&Record{}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("rollback %s: %w", describe(migration), err)
+	}
+	return nil
+}
+
+func step(tx *gorm.DB, fn func(tx *gorm.DB) error, sql string) error {
+	if fn != nil {
+		return fn(tx)
+	}
+	if sql != "" {
+		return tx.Exec(sql).Error
+	}
+	return nil
+}
+
+func (m *Migrator) ensureTable(db *gorm.DB) error {
+	return db.Table(m.tableName()).AutoMigrate(&Record{})
+}
+
+func (m *Migrator) records(db *gorm.DB) (map[string]Record, error) {
+	var rows []Record
+	if err := db.Table(m.tableName()).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	records := make(map[string]Record, len(rows))
+	for _, row := range rows {
+		records[row.Version] = row
+	}
+	return records, nil
+}
+
+func (m *Migrator) validate() error {
+	seen := make(map[string]bool, len(m.Migrations))
+	for _, migration := range m.Migrations {
+		if migration.Version == "" {
+			return fmt.Errorf("%w: %q has no version", ErrInvalidMigration, migration.Name)
+		}
+		if migration.Up != nil && migration.UpSQL != "" {
+			return fmt.Errorf("%w: %s defines both Up and UpSQL", ErrInvalidMigration, describe(migration))
+		}
+		if migration.Down != nil && migration.DownSQL != "" {
+			return fmt.Errorf("%w: %s defines both Down and DownSQL", ErrInvalidMigration, describe(migration))
+		}
+		if seen[migration.Version] {
+			return fmt.Errorf("%w: %s", ErrDuplicateVersion, migration.Version)
+		}
+		seen[migration.Version] = true
+	}
+	return nil
+}
+
+func (m *Migrator) sorted() []Migration {
+	sorted := make([]Migration, len(m.Migrations))
+	copy(sorted, m.Migrations)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+	return sorted
+}
+
+// session pins every statement to the primary connection so that reads of
+// the tracking table never hit a lagging replica.
+func (m *Migrator) session(ctx context.Context) *gorm.DB {
+	return m.DB.WithContext(ctx).Clauses(dbresolver.Write).Session(&gorm.Session{})
+}
+
+func (m *Migrator) tableName() string {
+	if m.TableName == "" {
+		return DefaultTableName
+	}
+	return m.TableName
+}
+
+func describe(migration Migration) string {
+	if migration.Name == "" {
+		return migration.Version
+	}
+	return migration.Version + "_" + migration.Name
+}
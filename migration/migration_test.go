@@ -0,0 +1,165 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type testUser struct {
+	ID   uint
+	Name string
+}
+
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "migration.db")), &gorm.Config{})
+	require.NoError(t, err)
+	return db
+}
+
+func testMigrations() []Migration {
+	return []Migration{
+		{
+			Version: "20240102000000",
+			Name:    "create_posts",
+			UpSQL:   "CREATE TABLE posts (id INTEGER PRIMARY KEY, title TEXT)",
+			DownSQL: "DROP TABLE posts",
+		},
+		{
+			Version: "20240101000000",
+			Name:    "create_users",
+			Up: func(tx *gorm.DB) error {
+				return tx.Migrator().CreateTable(&testUser{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&testUser{})
+			},
+		},
+	}
+}
+
+func TestMigrator_Up(t *testing.T) {
+	db := newTestDB(t)
+	migrator := NewMigrator(db, testMigrations())
+
+	applied, err := migrator.Up(context.Background())
+	require.NoError(t, err)
+	require.Len(t, applied, 2)
+	assert.Equal(t, "20240101000000", applied[0].Version)
+	assert.Equal(t, "20240102000000", applied[1].Version)
+
+	assert.True(t, db.Migrator().HasTable(&testUser{}))
+	assert.True(t, db.Migrator().HasTable("posts"))
+
+	applied, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+}
+
+func TestMigrator_Down(t *testing.T) {
+	db := newTestDB(t)
+	migrator := NewMigrator(db, testMigrations())
+
+	_, err := migrator.Up(context.Background())
+	require.NoError(t, err)
+
+	rolledBack, err := migrator.Down(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, rolledBack, 1)
+	assert.Equal(t, "20240102000000", rolledBack[0].Version)
+	assert.False(t, db.Migrator().HasTable("posts"))
+	assert.True(t, db.Migrator().HasTable(&testUser{}))
+
+	rolledBack, err = migrator.Down(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Len(t, rolledBack, 1)
+	assert.False(t, db.Migrator().HasTable(&testUser{}))
+}
+
+func TestMigrator_Status(t *testing.T) {
+	db := newTestDB(t)
+	migrations := testMigrations()
+	migrator := NewMigrator(db, migrations[1:])
+
+	_, err := migrator.Up(context.Background())
+	require.NoError(t, err)
+
+	migrator.Migrations = migrations
+	statuses, err := migrator.Status(context.Background())
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+
+	assert.True(t, statuses[0].Applied)
+	assert.Equal(t, 1, statuses[0].Batch)
+	assert.NotNil(t, statuses[0].AppliedAt)
+	assert.False(t, statuses[1].Applied)
+	assert.Nil(t, statuses[1].AppliedAt)
+}
+
+func TestMigrator_Fresh(t *testing.T) {
+	db := newTestDB(t)
+	migrator := NewMigrator(db, testMigrations())
+
+	_, err := migrator.Up(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, db.Create(&testUser{Name: "john"}).Error)
+
+	applied, err := migrator.Fresh(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, applied, 2)
+
+	var count int64
+	require.NoError(t, db.Model(&testUser{}).Count(&count).Error)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestMigrator_FailedMigrationIsNotRecorded(t *testing.T) {
+	db := newTestDB(t)
+	migrator := NewMigrator(db, []Migration{
+		{Version: "1", Name: "broken", UpSQL: "CREATE TABLE"},
+	})
+
+	_, err := migrator.Up(context.Background())
+	require.Error(t, err)
+
+	statuses, err := migrator.Status(context.Background())
+	require.NoError(t, err)
+	assert.False(t, statuses[0].Applied)
+}
+
+func TestMigrator_Irreversible(t *testing.T) {
+	db := newTestDB(t)
+	migrator := NewMigrator(db, []Migration{
+		{Version: "1", Name: "one_way", UpSQL: "CREATE TABLE one_way (id INTEGER)"},
+	})
+
+	_, err := migrator.Up(context.Background())
+	require.NoError(t, err)
+
+	_, err = migrator.Down(context.Background(), 1)
+	assert.True(t, errors.Is(err, ErrIrreversible))
+}
+
+func TestMigrator_Validate(t *testing.T) {
+	db := newTestDB(t)
+
+	_, err := NewMigrator(db, []Migration{{Version: "1"}, {Version: "1"}}).Up(context.Background())
+	assert.True(t, errors.Is(err, ErrDuplicateVersion))
+
+	_, err = NewMigrator(db, []Migration{{Name: "no_version"}}).Up(context.Background())
+	assert.True(t, errors.Is(err, ErrInvalidMigration))
+
+	_, err = NewMigrator(db, []Migration{{
+		Version: "1",
+		Up:      func(tx *gorm.DB) error { return nil },
+		UpSQL:   "SELECT 1",
+	}}).Up(context.Background())
+	assert.True(t, errors.Is(err, ErrInvalidMigration))
+}
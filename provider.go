@@ -0,0 +1,98 @@
+package ponodo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zerpto/ponodo/contracts"
+	"github.com/zerpto/ponodo/utils"
+)
+
+// RegisterProvider adds a service provider to the application. A provider
+// registered with the same name as an existing one replaces it in place,
+// which allows the built-in providers to be swapped for custom ones.
+func (app *App) RegisterProvider(provider contracts.ServiceProviderContract) {
+	for i, registered := range app.Providers {
+		if registered.Name() == provider.Name() {
+			app.Providers[i] = provider
+			return
+		}
+	}
+	app.Providers = append(app.Providers, provider)
+}
+
+// RemoveProvider removes the service provider with the given name.
+// This is used to disable a built-in provider such as "database" for
+// commands or services that do not need it.
+func (app *App) RemoveProvider(name string) {
+	providers := app.Providers[:0]
+	for _, provider := range app.Providers {
+		if provider.Name() != name {
+			providers = append(providers, provider)
+		}
+	}
+	app.Providers = providers
+}
+
+// GetProviders returns the registered service providers in registration order.
+func (app *App) GetProviders() []contracts.ServiceProviderContract {
+	return app.Providers
+}
+
+// Shutdown runs the Shutdown phase of every registered provider in reverse
+// dependency order. All providers are given the chance to shut down and
+// their errors are joined together.
+func (app *App) Shutdown() error {
+	var errs []error
+	for i := len(app.started) - 1; i >= 0; i-- {
+		provider := app.started[i]
+		if err := provider.Shutdown(app); err != nil {
+			errs = append(errs, &ProviderError{Provider: provider.Name(), Phase: ProviderPhaseShutdown, Err: err})
+		}
+	}
+	app.started = nil
+	return errors.Join(errs...)
+}
+
+func (app *App) sortedProviders() ([]contracts.ServiceProviderContract, error) {
+	names := make([]string, 0, len(app.Providers))
+	dependencies := make(map[string][]string, len(app.Providers))
+	byName := make(map[string]contracts.ServiceProviderContract, len(app.Providers))
+	for _, provider := range app.Providers {
+		names = append(names, provider.Name())
+		dependencies[provider.Name()] = provider.DependsOn()
+		byName[provider.Name()] = provider
+	}
+
+	sortedNames, err := utils.SortByDependencies(names, dependencies)
+	if err != nil {
+		return nil, fmt.Errorf("sort providers: %w", err)
+	}
+
+	sorted := make([]contracts.ServiceProviderContract, len(sortedNames))
+	for i, name := range sortedNames {
+		sorted[i] = byName[name]
+	}
+	return sorted, nil
+}
+
+func (app *App) bootProviders() error {
+	providers, err := app.sortedProviders()
+	if err != nil {
+		return err
+	}
+
+	for _, provider := range providers {
+		if err := provider.Register(app); err != nil {
+			return &ProviderError{Provider: provider.Name(), Phase: ProviderPhaseRegister, Err: err}
+		}
+		app.started = append(app.started, provider)
+	}
+
+	for _, provider := range providers {
+		if err := provider.Boot(app); err != nil {
+			return &ProviderError{Provider: provider.Name(), Phase: ProviderPhaseBoot, Err: err}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package ponodo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zerpto/ponodo/contracts"
+)
+
+type recordingProvider struct {
+	name      string
+	dependsOn []string
+	events    *[]string
+	bootErr   error
+}
+
+func (p *recordingProvider) Name() string        { return p.name }
+func (p *recordingProvider) DependsOn() []string { return p.dependsOn }
+
+func (p *recordingProvider) Register(app contracts.AppContract) error {
+	*p.events = append(*p.events, "register:"+p.name)
+	return nil
+}
+
+func (p *recordingProvider) Boot(app contracts.AppContract) error {
+	*p.events = append(*p.events, "boot:"+p.name)
+	return p.bootErr
+}
+
+func (p *recordingProvider) Shutdown(app contracts.AppContract) error {
+	*p.events = append(*p.events, "shutdown:"+p.name)
+	return nil
+}
+
+func TestNewApp_DefaultProviders(t *testing.T) {
+	app := NewApp()
+
+	var names []string
+	for _, provider := range app.GetProviders() {
+		names = append(names, provider.Name())
+	}
+	assert.Equal(t, []string{"logger", "tracing", "database", "validator"}, names)
+}
+
+func TestApp_RegisterProvider_Replaces(t *testing.T) {
+	var events []string
+	app := &App{}
+	app.RegisterProvider(&recordingProvider{name: "database", events: &events})
+	replacement := &recordingProvider{name: "database", events: &events}
+	app.RegisterProvider(replacement)
+
+	require.Len(t, app.GetProviders(), 1)
+	assert.Same(t, replacement, app.GetProviders()[0])
+}
+
+func TestApp_RemoveProvider(t *testing.T) {
+	app := NewApp().(*App)
+	app.RemoveProvider("database")
+
+	for _, provider := range app.GetProviders() {
+		assert.NotEqual(t, "database", provider.Name())
+	}
+	assert.Len(t, app.GetProviders(), 3)
+}
+
+func TestApp_RemoveProvider_AnyDefault(t *testing.T) {
+	for _, name := range []string{"logger", "tracing", "database", "validator"} {
+		t.Run(name, func(t *testing.T) {
+			app := NewApp().(*App)
+			app.RemoveProvider(name)
+
+			providers, err := app.sortedProviders()
+			require.NoError(t, err)
+			assert.Len(t, providers, 3)
+		})
+	}
+}
+
+func TestApp_ProviderLifecycleOrder(t *testing.T) {
+	var events []string
+	app := &App{}
+	app.RegisterProvider(&recordingProvider{name: "cache", dependsOn: []string{"database"}, events: &events})
+	app.RegisterProvider(&recordingProvider{name: "database", events: &events})
+
+	require.NoError(t, app.SetupBaseDependencies())
+	require.NoError(t, app.Shutdown())
+
+	assert.Equal(t, []string{
+		"register:database",
+		"register:cache",
+		"boot:database",
+		"boot:cache",
+		"shutdown:cache",
+		"shutdown:database",
+	}, events)
+}
+
+func TestApp_BootProvidersError(t *testing.T) {
+	var events []string
+	app := &App{}
+	app.RegisterProvider(&recordingProvider{name: "database", events: &events, bootErr: errors.New("boom")})
+
+	err := app.bootProviders()
+	require.Error(t, err)
+	var providerErr *ProviderError
+	require.ErrorAs(t, err, &providerErr)
+	assert.Equal(t, "database", providerErr.Provider)
+	assert.Equal(t, ProviderPhaseBoot, providerErr.Phase)
+
+	require.NoError(t, app.Shutdown())
+	assert.Contains(t, events, "shutdown:database")
+}
+
+func TestApp_BootProvidersUnknownDependency(t *testing.T) {
+	var events []string
+	app := &App{}
+	app.RegisterProvider(&recordingProvider{name: "cache", dependsOn: []string{"redis"}, events: &events})
+
+	err := app.bootProviders()
+	require.Error(t, err)
+	assert.Empty(t, events)
+}
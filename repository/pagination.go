@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/zerpto/ponodo/request"
+	"github.com/zerpto/ponodo/response"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// The page size limits are the ones of request.Pagination, so that a query
+// built from a request is never clamped differently.
+const (
+	// DefaultPerPage is the page size used when a query does not set one.
+	DefaultPerPage = request.DefaultPerPage
+
+	// MaxPerPage is the largest page size a query may request.
+	MaxPerPage = request.MaxPerPage
+)
+
+// Page is a single page of records together with its pagination metadata,
+// which can be passed as is to the response metadata.
+type Page[T any] struct {
+	Items      []T
+	Pagination *response.MetaPagination
+}
+
+// cursor is the decoded form of an opaque pagination cursor. It holds the
+// order column values of the record the page starts after, and whether the
+// page is read backwards from that record.
+type cursor struct {
+	Values   []json.RawMessage `json:"v"`
+	Backward bool              `json:"b,omitempty"`
+}
+
+// List returns one page of the records matching the query using offset
+// pagination. The metadata carries the page number, page size, total
+// number of matching records and the number of pages.
+func (r *Repository[T]) List(ctx context.Context, query Query) (*Page[T], error) {
+	db, s, err := r.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if db, err = applyFilters(db, s, query.Filters); err != nil {
+		return nil, err
+	}
+	fields, desc, err := orderColumns(s, query.Sorts)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	if err := db.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	page := max(query.Page, 1)
+	perPage := clampPerPage(query.PerPage)
+
+	items := make([]T, 0, perPage)
+	err = applyOrder(db, fields, desc).
+		Offset((page - 1) * perPage).
+		Limit(perPage).
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int((total + int64(perPage) - 1) / int64(perPage))
+	return &Page[T]{
+		Items: items,
+		Pagination: &response.MetaPagination{
+			Page:       page,
+			PerPage:    perPage,
+			Total:      &total,
+			TotalPages: &totalPages,
+		},
+	}, nil
+}
+
+// ListCursor returns one page of the records matching the query using
+// keyset pagination, which stays fast on large tables and does not skip or
+// repeat records when rows are inserted between requests. The metadata
+// carries the cursors of the next and previous pages, which are empty when
+// there is no such page. Sort fields should not contain NULL values.
+func (r *Repository[T]) ListCursor(ctx context.Context, query Query) (*Page[T], error) {
+	db, s, err := r.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if db, err = applyFilters(db, s, query.Filters); err != nil {
+		return nil, err
+	}
+	fields, desc, err := orderColumns(s, query.Sorts)
+	if err != nil {
+		return nil, err
+	}
+
+	var after *cursor
+	if query.Cursor != "" {
+		if after, err = decodeCursor(query.Cursor, len(fields)); err != nil {
+			return nil, err
+		}
+		expression, err := keysetExpression(fields, desc, after)
+		if err != nil {
+			return nil, err
+		}
+		db = db.Where(expression)
+	}
+
+	backward := after != nil && after.Backward
+	order := desc
+	if backward {
+		order = make([]bool, len(desc))
+		for i := range desc {
+			order[i] = !desc[i]
+		}
+	}
+
+	perPage := clampPerPage(query.PerPage)
+	items := make([]T, 0, perPage+1)
+	if err := applyOrder(db, fields, order).Limit(perPage + 1).Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	hasMore := len(items) > perPage
+	if hasMore {
+		items = items[:perPage]
+	}
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	pagination := &response.MetaPagination{PerPage: perPage}
+	if len(items) > 0 {
+		hasNext, hasPrev := hasMore, after != nil
+		if backward {
+			hasNext, hasPrev = true, hasMore
+		}
+		if hasNext {
+			if pagination.NextCursor, err = encodeCursor(ctx, fields, items[len(items)-1], false); err != nil {
+				return nil, err
+			}
+		}
+		if hasPrev {
+			if pagination.PrevCursor, err = encodeCursor(ctx, fields, items[0], true); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &Page[T]{
+		Items:      items,
+		Pagination: pagination,
+	}, nil
+}
+
+// keysetExpression builds the condition selecting the records that come
+// after the cursor in the given order, or before it for a backward cursor:
+// (a > x) OR (a = x AND b > y) and so on for every order column.
+func keysetExpression(fields []*schema.Field, desc []bool, after *cursor) (clause.Expression, error) {
+	values := make([]any, len(fields))
+	for i, field := range fields {
+		value := reflect.New(field.FieldType)
+		if err := json.Unmarshal(after.Values[i], value.Interface()); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+		}
+		values[i] = value.Elem().Interface()
+	}
+
+	alternatives := make([]clause.Expression, 0, len(fields))
+	for i, field := range fields {
+		conditions := make([]clause.Expression, 0, i+1)
+		for j := 0; j < i; j++ {
+			conditions = append(conditions, clause.Eq{Column: column(fields[j]), Value: values[j]})
+		}
+
+		if desc[i] != after.Backward {
+			conditions = append(conditions, clause.Lt{Column: column(field), Value: values[i]})
+		} else {
+			conditions = append(conditions, clause.Gt{Column: column(field), Value: values[i]})
+		}
+		alternatives = append(alternatives, clause.And(conditions...))
+	}
+	return clause.Or(alternatives...), nil
+}
+
+func encodeCursor[T any](ctx context.Context, fields []*schema.Field, item T, backward bool) (string, error) {
+	c := cursor{Values: make([]json.RawMessage, len(fields)), Backward: backward}
+	rv := reflect.ValueOf(&item).Elem()
+	for i, field := range fields {
+		value, _ := field.ValueOf(ctx, rv)
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		c.Values[i] = raw
+	}
+
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func decodeCursor(encoded string, columns int) (*cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	var c cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if len(c.Values) != columns {
+		return nil, fmt.Errorf("%w: expected %d values, got %d", ErrInvalidCursor, columns, len(c.Values))
+	}
+	return &c, nil
+}
+
+func clampPerPage(perPage int) int {
+	if perPage <= 0 {
+		return DefaultPerPage
+	}
+	return min(perPage, MaxPerPage)
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func names(users []testUser) []string {
+	result := make([]string, len(users))
+	for i, user := range users {
+		result[i] = user.Name
+	}
+	return result
+}
+
+func TestRepository_List(t *testing.T) {
+	repo, _ := newTestRepository(t, 7)
+
+	page, err := repo.List(context.Background(), Query{
+		Filters: []Filter{Where("age", OpGte, 21)},
+		Sorts:   []Sort{{Field: "name", Desc: true}},
+		Page:    2,
+		PerPage: 2,
+	})
+	require.NoError(t, err)
+
+	// Ages cycle through 21, 22, 20, so users 3 and 6 are filtered out.
+	assert.Equal(t, []string{"user-04", "user-02"}, names(page.Items))
+	assert.Equal(t, 2, page.Pagination.Page)
+	assert.Equal(t, 2, page.Pagination.PerPage)
+	require.NotNil(t, page.Pagination.Total)
+	require.NotNil(t, page.Pagination.TotalPages)
+	assert.Equal(t, int64(5), *page.Pagination.Total)
+	assert.Equal(t, 3, *page.Pagination.TotalPages)
+}
+
+func TestRepository_List_Defaults(t *testing.T) {
+	repo, _ := newTestRepository(t, 3)
+
+	page, err := repo.List(context.Background(), Query{PerPage: 1000})
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Pagination.Page)
+	assert.Equal(t, MaxPerPage, page.Pagination.PerPage)
+	assert.Equal(t, []string{"user-01", "user-02", "user-03"}, names(page.Items))
+
+	_, err = repo.List(context.Background(), Query{Sorts: []Sort{{Field: "unknown"}}})
+	assert.ErrorIs(t, err, ErrUnknownField)
+}
+
+func TestRepository_ListCursor(t *testing.T) {
+	repo, _ := newTestRepository(t, 5)
+	ctx := context.Background()
+	query := Query{Sorts: []Sort{{Field: "age"}}, PerPage: 2}
+
+	// Ages are 21, 22, 20, 21, 22 for users 1 to 5.
+	first, err := repo.ListCursor(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-03", "user-01"}, names(first.Items))
+	assert.Empty(t, first.Pagination.PrevCursor)
+	require.NotEmpty(t, first.Pagination.NextCursor)
+
+	query.Cursor = first.Pagination.NextCursor
+	second, err := repo.ListCursor(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-04", "user-02"}, names(second.Items))
+	require.NotEmpty(t, second.Pagination.PrevCursor)
+
+	query.Cursor = second.Pagination.NextCursor
+	last, err := repo.ListCursor(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-05"}, names(last.Items))
+	assert.Empty(t, last.Pagination.NextCursor)
+
+	query.Cursor = second.Pagination.PrevCursor
+	previous, err := repo.ListCursor(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, names(first.Items), names(previous.Items))
+	assert.Empty(t, previous.Pagination.PrevCursor)
+	assert.Equal(t, first.Pagination.NextCursor, previous.Pagination.NextCursor)
+}
+
+func TestRepository_ListCursor_InvalidCursor(t *testing.T) {
+	repo, _ := newTestRepository(t, 1)
+
+	_, err := repo.ListCursor(context.Background(), Query{Cursor: "not a cursor"})
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
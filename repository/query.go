@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Operator is the comparison applied by a Filter.
+type Operator string
+
+// Supported filter operators.
+const (
+	OpEq      Operator = "eq"
+	OpNotEq   Operator = "neq"
+	OpGt      Operator = "gt"
+	OpGte     Operator = "gte"
+	OpLt      Operator = "lt"
+	OpLte     Operator = "lte"
+	OpLike    Operator = "like"
+	OpIn      Operator = "in"
+	OpIsNull  Operator = "null"
+	OpNotNull Operator = "not_null"
+)
+
+// Filter restricts a query to records whose field matches the value. The
+// field is either the struct field name or the column name of the model
+// and is validated against its schema, so filters built from user input
+// cannot inject SQL. OpIn expects a slice value and OpIsNull and OpNotNull
+// ignore the value.
+type Filter struct {
+	Field    string
+	Operator Operator
+	Value    any
+}
+
+// Where returns a filter comparing the field with the operator.
+func Where(field string, operator Operator, value any) Filter {
+	return Filter{Field: field, Operator: operator, Value: value}
+}
+
+// Sort orders a query by a field, ascending unless Desc is set.
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Query describes the records returned by List and ListCursor. Records are
+// ordered by the sorts and then by primary key, so pages are stable even
+// when the sort fields contain duplicates.
+type Query struct {
+	Filters []Filter
+	Sorts   []Sort
+
+	// Page is the one-based page number used by List.
+	Page int
+
+	// Cursor is the opaque position returned as NextCursor or PrevCursor
+	// of a previous ListCursor call. It is empty for the first page.
+	Cursor string
+
+	// PerPage is the page size, DefaultPerPage when zero and at most
+	// MaxPerPage.
+	PerPage int
+}
+
+func applyFilters(db *gorm.DB, s *schema.Schema, filters []Filter) (*gorm.DB, error) {
+	for _, filter := range filters {
+		field, err := lookupField(s, filter.Field)
+		if err != nil {
+			return nil, err
+		}
+
+		expression, err := filterExpression(column(field), filter)
+		if err != nil {
+			return nil, err
+		}
+		db = db.Where(expression)
+	}
+	return db, nil
+}
+
+func filterExpression(column clause.Column, filter Filter) (clause.Expression, error) {
+	switch filter.Operator {
+	case OpEq, "":
+		return clause.Eq{Column: column, Value: filter.Value}, nil
+	case OpNotEq:
+		return clause.Neq{Column: column, Value: filter.Value}, nil
+	case OpGt:
+		return clause.Gt{Column: column, Value: filter.Value}, nil
+	case OpGte:
+		return clause.Gte{Column: column, Value: filter.Value}, nil
+	case OpLt:
+		return clause.Lt{Column: column, Value: filter.Value}, nil
+	case OpLte:
+		return clause.Lte{Column: column, Value: filter.Value}, nil
+	case OpLike:
+		return clause.Like{Column: column, Value: filter.Value}, nil
+	case OpIn:
+		values := reflect.ValueOf(filter.Value)
+		if values.Kind() != reflect.Slice && values.Kind() != reflect.Array {
+			return nil, fmt.Errorf("repository: %s filter on %s expects a slice", filter.Operator, filter.Field)
+		}
+		in := clause.IN{Column: column, Values: make([]any, values.Len())}
+		for i := range in.Values {
+			in.Values[i] = values.Index(i).Interface()
+		}
+		return in, nil
+	case OpIsNull:
+		return clause.Eq{Column: column, Value: nil}, nil
+	case OpNotNull:
+		return clause.Neq{Column: column, Value: nil}, nil
+	default:
+		return nil, fmt.Errorf("repository: unsupported operator %q", filter.Operator)
+	}
+}
+
+// orderColumns returns the sort fields followed by the primary key, which
+// acts as a tie breaker unless it is already sorted on.
+func orderColumns(s *schema.Schema, sorts []Sort) ([]*schema.Field, []bool, error) {
+	fields := make([]*schema.Field, 0, len(sorts)+1)
+	desc := make([]bool, 0, len(sorts)+1)
+	hasPrimary := false
+	for _, sort := range sorts {
+		field, err := lookupField(s, sort.Field)
+		if err != nil {
+			return nil, nil, err
+		}
+		fields = append(fields, field)
+		desc = append(desc, sort.Desc)
+		hasPrimary = hasPrimary || field == s.PrioritizedPrimaryField
+	}
+
+	if !hasPrimary && s.PrioritizedPrimaryField != nil {
+		// Keep the direction of the last sort so that a single descending
+		// sort is not broken up by an ascending tie breaker.
+		fields = append(fields, s.PrioritizedPrimaryField)
+		desc = append(desc, len(sorts) > 0 && sorts[len(sorts)-1].Desc)
+	}
+	return fields, desc, nil
+}
+
+func applyOrder(db *gorm.DB, fields []*schema.Field, desc []bool) *gorm.DB {
+	columns := make([]clause.OrderByColumn, len(fields))
+	for i, field := range fields {
+		columns[i] = clause.OrderByColumn{
+			Column: column(field),
+			Desc:   desc[i],
+		}
+	}
+	return db.Order(clause.OrderBy{Columns: columns})
+}
+
+func column(field *schema.Field) clause.Column {
+	return clause.Column{Table: clause.CurrentTable, Name: field.DBName}
+}
+
+func lookupField(s *schema.Schema, name string) (*schema.Field, error) {
+	field := s.LookUpField(name)
+	if field == nil || field.DBName == "" {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownField, name)
+	}
+	return field, nil
+}
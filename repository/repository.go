@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/zerpto/ponodo"
+	"github.com/zerpto/ponodo/contracts"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+var (
+	// ErrNotFound is returned when no record matches the given primary key.
+	// It is always joined with gorm.ErrRecordNotFound, so response.Error
+	// maps it to 404 Not Found.
+	ErrNotFound = errors.New("repository: record not found")
+
+	// ErrUnknownField is returned when a filter or sort refers to a field
+	// that does not exist on the model.
+	ErrUnknownField = errors.New("repository: unknown field")
+
+	// ErrInvalidCursor is returned when a pagination cursor cannot be decoded
+	// or does not match the sort order of the query.
+	ErrInvalidCursor = errors.New("repository: invalid cursor")
+)
+
+// Repository provides the common CRUD operations for the GORM model T.
+// Every method resolves its connection with ponodo.DbFromContext, so calls
+// made inside ponodo.WithTransaction join the surrounding transaction.
+type Repository[T any] struct {
+	App contracts.AppContract
+}
+
+// New creates a repository for the model T backed by the application's
+// default database connection.
+func New[T any](app contracts.AppContract) *Repository[T] {
+	return &Repository[T]{
+		App: app,
+	}
+}
+
+// DB returns the connection used by the repository for the given context,
+// scoped to the model T. It can be used to build queries that the
+// repository does not cover.
+func (r *Repository[T]) DB(ctx context.Context) *gorm.DB {
+	return ponodo.DbFromContext(ctx, r.App).Model(new(T))
+}
+
+// FindByID returns the record with the given primary key. An error
+// wrapping ErrNotFound is returned when it does not exist or has been
+// soft deleted.
+func (r *Repository[T]) FindByID(ctx context.Context, id any) (*T, error) {
+	entity := new(T)
+	err := ponodo.DbFromContext(ctx, r.App).
+		Where(clause.Eq{Column: clause.PrimaryColumn, Value: id}).
+		Take(entity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// Create inserts the entity and fills in its generated fields such as the
+// primary key and timestamps.
+func (r *Repository[T]) Create(ctx context.Context, entity *T) error {
+	return ponodo.DbFromContext(ctx, r.App).Create(entity).Error
+}
+
+// Update saves every field of an existing entity, including zero values.
+// ErrNotFound is returned when no record with its primary key exists.
+func (r *Repository[T]) Update(ctx context.Context, entity *T) error {
+	result := ponodo.DbFromContext(ctx, r.App).Model(entity).Select("*").Updates(entity)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return r.ensureExists(ctx, entity)
+	}
+	return nil
+}
+
+// Delete removes the record with the given primary key. Models embedding
+// gorm.Model or a gorm.DeletedAt field are soft deleted and no longer
+// returned by the repository. ErrNotFound is returned when no record with
+// the primary key exists.
+func (r *Repository[T]) Delete(ctx context.Context, id any) error {
+	result := ponodo.DbFromContext(ctx, r.App).
+		Where(clause.Eq{Column: clause.PrimaryColumn, Value: id}).
+		Delete(new(T))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		_, err := r.FindByID(ctx, id)
+		return err
+	}
+	return nil
+}
+
+// Exists reports whether at least one record matches all of the filters.
+func (r *Repository[T]) Exists(ctx context.Context, filters ...Filter) (bool, error) {
+	db, s, err := r.prepare(ctx)
+	if err != nil {
+		return false, err
+	}
+	if db, err = applyFilters(db, s, filters); err != nil {
+		return false, err
+	}
+
+	var count int64
+	if err := db.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ensureExists returns an error wrapping ErrNotFound when no record with
+// the primary key of the entity exists. It is called when a statement
+// affected no rows, which does not mean the record is missing: MySQL, for
+// one, only counts the rows whose values actually changed.
+func (r *Repository[T]) ensureExists(ctx context.Context, entity *T) error {
+	db, s, err := r.prepare(ctx)
+	if err != nil {
+		return err
+	}
+	value := reflect.ValueOf(entity).Elem()
+	for _, field := range s.PrimaryFields {
+		key, zero := field.ValueOf(ctx, value)
+		if zero {
+			return fmt.Errorf("%w: %w", ErrNotFound, gorm.ErrRecordNotFound)
+		}
+		db = db.Where(clause.Eq{Column: column(field), Value: key})
+	}
+
+	err = db.Take(new(T)).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	return err
+}
+
+// prepare returns a model scoped connection together with the parsed
+// schema of T, which is used to validate filter and sort fields.
+func (r *Repository[T]) prepare(ctx context.Context) (*gorm.DB, *schema.Schema, error) {
+	db := r.DB(ctx)
+	if err := db.Statement.Parse(new(T)); err != nil {
+		return nil, nil, err
+	}
+	return db, db.Statement.Schema, nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/zerpto/ponodo"
+)
+
+type testUser struct {
+	gorm.Model
+	Name  string
+	Email string
+	Age   int
+}
+
+func newTestRepository(t *testing.T, users int) (*Repository[testUser], *ponodo.App) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "repository.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&testUser{}))
+
+	app := &ponodo.App{DB: db}
+	repo := New[testUser](app)
+	for i := 1; i <= users; i++ {
+		user := &testUser{Name: fmt.Sprintf("user-%02d", i), Email: fmt.Sprintf("user%d@example.com", i), Age: 20 + i%3}
+		require.NoError(t, repo.Create(context.Background(), user))
+	}
+	return repo, app
+}
+
+func TestRepository_FindByID(t *testing.T) {
+	repo, _ := newTestRepository(t, 2)
+
+	user, err := repo.FindByID(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, "user-02", user.Name)
+
+	_, err = repo.FindByID(context.Background(), 99)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+}
+
+func TestRepository_Update(t *testing.T) {
+	repo, _ := newTestRepository(t, 1)
+	ctx := context.Background()
+
+	user, err := repo.FindByID(ctx, 1)
+	require.NoError(t, err)
+	user.Name = "renamed"
+	user.Age = 0
+	require.NoError(t, repo.Update(ctx, user))
+
+	user, err = repo.FindByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", user.Name)
+	assert.Equal(t, 0, user.Age)
+
+	missing := &testUser{Model: gorm.Model{ID: 99}}
+	assert.ErrorIs(t, repo.Update(ctx, missing), ErrNotFound)
+}
+
+func TestRepository_Update_NoRowsAffected(t *testing.T) {
+	repo, app := newTestRepository(t, 1)
+	ctx := context.Background()
+
+	// MySQL reports no affected rows when the values did not change.
+	require.NoError(t, app.DB.Callback().Update().After("gorm:update").Register("test:unchanged", func(db *gorm.DB) {
+		db.RowsAffected = 0
+	}))
+
+	user, err := repo.FindByID(ctx, 1)
+	require.NoError(t, err)
+	assert.NoError(t, repo.Update(ctx, user))
+
+	missing := &testUser{Model: gorm.Model{ID: 99}}
+	assert.ErrorIs(t, repo.Update(ctx, missing), ErrNotFound)
+}
+
+func TestRepository_SoftDelete(t *testing.T) {
+	repo, app := newTestRepository(t, 1)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Delete(ctx, 1))
+	_, err := repo.FindByID(ctx, 1)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, 1), ErrNotFound)
+
+	var count int64
+	require.NoError(t, app.DB.Unscoped().Model(&testUser{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestRepository_Exists(t *testing.T) {
+	repo, _ := newTestRepository(t, 3)
+	ctx := context.Background()
+
+	exists, err := repo.Exists(ctx, Where("email", OpEq, "user2@example.com"))
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = repo.Exists(ctx, Where("Name", OpIn, []string{"nobody", "someone"}))
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = repo.Exists(ctx, Where("password; DROP TABLE users", OpEq, "x"))
+	assert.ErrorIs(t, err, ErrUnknownField)
+}
+
+func TestRepository_JoinsTransaction(t *testing.T) {
+	repo, _ := newTestRepository(t, 0)
+	ctx := context.Background()
+
+	err := ponodo.WithTransaction(ctx, repo.App, func(ctx context.Context) error {
+		if err := repo.Create(ctx, &testUser{Name: "rolled back"}); err != nil {
+			return err
+		}
+		return errors.New("abort")
+	})
+	require.Error(t, err)
+
+	exists, err := repo.Exists(ctx, Where("name", OpEq, "rolled back"))
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
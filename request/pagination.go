@@ -0,0 +1,47 @@
+package request
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultPerPage is the page size used when the request does not set one.
+	DefaultPerPage = 15
+
+	// MaxPerPage is the largest page size a client may request.
+	MaxPerPage = 100
+)
+
+// Pagination holds the pagination parameters of a request. Page is used
+// for offset pagination and Cursor for cursor pagination, in which case
+// Page is zero.
+type Pagination struct {
+	Page    int
+	PerPage int
+	Cursor  string
+}
+
+// ParsePagination reads the page, per_page and cursor query parameters of
+// the request. Missing or invalid values fall back to the first page and
+// DefaultPerPage, and per_page is clamped to MaxPerPage, so the result can
+// be used without further validation.
+func ParsePagination(ctx *gin.Context) Pagination {
+	pagination := Pagination{
+		PerPage: DefaultPerPage,
+		Cursor:  ctx.Query("cursor"),
+	}
+
+	if perPage, err := strconv.Atoi(ctx.Query("per_page")); err == nil && perPage > 0 {
+		pagination.PerPage = min(perPage, MaxPerPage)
+	}
+
+	if pagination.Cursor == "" {
+		pagination.Page = 1
+		if page, err := strconv.Atoi(ctx.Query("page")); err == nil && page > 0 {
+			pagination.Page = page
+		}
+	}
+	return pagination
+}
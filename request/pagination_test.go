@@ -0,0 +1,55 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		query    string
+		expected Pagination
+	}{
+		{
+			name:     "defaults",
+			query:    "",
+			expected: Pagination{Page: 1, PerPage: DefaultPerPage},
+		},
+		{
+			name:     "page and per page",
+			query:    "?page=3&per_page=20",
+			expected: Pagination{Page: 3, PerPage: 20},
+		},
+		{
+			name:     "per page is clamped",
+			query:    "?page=2&per_page=1000",
+			expected: Pagination{Page: 2, PerPage: MaxPerPage},
+		},
+		{
+			name:     "invalid values fall back to defaults",
+			query:    "?page=-1&per_page=abc",
+			expected: Pagination{Page: 1, PerPage: DefaultPerPage},
+		},
+		{
+			name:     "cursor",
+			query:    "?cursor=abc&page=4&per_page=5",
+			expected: Pagination{Cursor: "abc", PerPage: 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/users"+tt.query, nil)
+
+			assert.Equal(t, tt.expected, ParsePagination(c))
+		})
+	}
+}
@@ -7,14 +7,24 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
-	"github.com/zerpto/ponodo/utils"
+	"github.com/zerpto/ponodo/apperror"
+	"github.com/zerpto/ponodo/timing"
 	"github.com/zerpto/ponodo/validation"
 )
 
 // MetaPagination represents pagination metadata for API responses.
-// It can be extended with fields like page, limit, total, etc.
-// to provide pagination information in response metadata.
+// Offset pagination fills in the page, total and total pages, while cursor
+// pagination fills in the opaque next and previous cursors. Fields that do
+// not apply to the pagination style in use are omitted from the JSON, while
+// a total of zero on an empty offset page is still written. Total and
+// TotalPages are pointers so that the two cases can be told apart.
 type MetaPagination struct {
+	Page       int    `json:"page,omitempty"`
+	PerPage    int    `json:"per_page"`
+	Total      *int64 `json:"total,omitempty"`
+	TotalPages *int   `json:"total_pages,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 // Meta represents response metadata that provides additional information
@@ -37,25 +47,35 @@ type BaseSuccessResponse[T any] struct {
 // BaseErrorResponse represents a standardized error response structure.
 // It includes an error message and detailed error information, providing
 // a consistent format for all error responses across the application.
+// Code and Details are set for application errors, and Cause carries the
+// internal cause outside of release mode only.
 type BaseErrorResponse struct {
 	Message string `json:"message"`
 	Error   any    `json:"error"`
+	Code    string `json:"code,omitempty"`
+	Details any    `json:"details,omitempty"`
+	Cause   string `json:"cause,omitempty"`
 }
 
 // Success sends a standardized success response with the provided data.
 // It includes metadata such as timestamp and execution duration, and
-// uses the specified HTTP status code for the response.
+// uses the specified HTTP status code for the response. The execution
+// duration is measured from the start time recorded by timing.Middleware
+// and is zero when the middleware is not installed.
 func Success[T any](ctx *gin.Context, statusCode int, data T) {
+	success(ctx, statusCode, data, nil)
+}
+
+func success[T any](ctx *gin.Context, statusCode int, data T, pagination *MetaPagination) {
 	if statusCode == 0 {
 		statusCode = 200
 	}
-	timestamp := time.Now()
-	startAt := time.Now()
 	ctx.JSON(statusCode, &BaseSuccessResponse[T]{
 		Data: data,
 		Meta: &Meta{
-			Timestamp:         timestamp,
-			ExecutionDuration: time.Since(startAt).Nanoseconds(),
+			Timestamp:         time.Now(),
+			Pagination:        pagination,
+			ExecutionDuration: timing.Finish(ctx).Nanoseconds(),
 		},
 	})
 	ctx.Abort()
@@ -64,37 +84,103 @@ func Success[T any](ctx *gin.Context, statusCode int, data T) {
 
 // Error sends a standardized error response with the provided error.
 // It handles validation errors by formatting them into a structured format,
-// and uses the specified HTTP status code for the response.
+// and uses the specified HTTP status code for the response. A zero status
+// code is derived from the error with apperror.StatusOf, so application
+// errors use their own status, validation errors 422 and GORM record not
+// found errors 404. The response is sent as RFC 9457 problem details when
+// that format is selected with WithErrorFormat or requested through the
+// Accept header.
 func Error(ctx *gin.Context, statusCode int, data error) {
 	if statusCode == 0 {
-		statusCode = 500
+		statusCode = apperror.StatusOf(data)
+	}
+
+	content := newErrorContent(statusCode, data)
+	if NegotiateErrorFormat(ctx) == ErrorFormatProblem {
+		problem(ctx, statusCode, content)
+		return
 	}
 
 	var errorContent any
 
-	var validationErrors validator.ValidationErrors
-	if errors.As(data, &validationErrors) {
+	if content.fields != nil {
 
 		// handle validation error
-		fieldsWithErrorValue := make(map[string][]string)
-		for _, validationError := range validationErrors {
-			fieldName := utils.ToSnakeCase(validationError.Field())
-			message := validation.GetValidationMessage(validationError)
-			fieldsWithErrorValue[fieldName] = append(fieldsWithErrorValue[validationError.Field()], message)
-		}
-		errorContent = fieldsWithErrorValue
+		errorContent = content.fields
 
 	} else {
 
 		// handle generic error
 		errorContent = map[string]any{
-			"generic": []string{data.Error()},
+			"generic": []string{content.message},
 		}
 	}
 
 	ctx.JSON(statusCode, &BaseErrorResponse{
 		Message: http.StatusText(statusCode),
 		Error:   errorContent,
+		Code:    content.code,
+		Details: content.details,
+		Cause:   content.cause,
 	})
 	ctx.Abort()
 }
+
+// errorContent is the client facing content of an error, shared by the
+// default and the problem details formats.
+type errorContent struct {
+	message string
+	fields  map[string][]string
+	code    string
+	details any
+	cause   string
+}
+
+// newErrorContent extracts what may be shown to the client from the error.
+// Application errors expose their user-safe message and never their cause
+// in release mode. Other errors are shown as is outside release mode. In
+// release mode they are replaced by the user-safe message of the
+// application error they map to with apperror.From, or by the status text.
+func newErrorContent(statusCode int, data error) errorContent {
+	content := errorContent{}
+	release := gin.Mode() == gin.ReleaseMode
+
+	var validationErrors validator.ValidationErrors
+	var appErr *apperror.Error
+	switch {
+	case errors.As(data, &validationErrors):
+		content.message = apperror.ErrValidation.Message
+		content.fields = validationErrorMap(validationErrors)
+	case errors.As(data, &appErr):
+		content.message = appErr.Message
+		content.code = appErr.Code
+		content.details = appErr.Details
+		if appErr.Cause != nil && !release {
+			content.cause = appErr.Cause.Error()
+		}
+	case data == nil:
+		content.message = http.StatusText(statusCode)
+	case !release:
+		content.message = data.Error()
+	default:
+		// The error text may name tables, hosts or tenants, so release
+		// mode only shows the message of the application error it maps
+		// to, or the status text when it maps to another status.
+		content.message = http.StatusText(statusCode)
+		if mapped := apperror.From(data); mapped.Status == statusCode && statusCode < http.StatusInternalServerError {
+			content.message = mapped.Message
+		}
+	}
+	return content
+}
+
+// validationErrorMap groups the validation messages by field path.
+func validationErrorMap(validationErrors validator.ValidationErrors) map[string][]string {
+	fieldsWithErrorValue := make(map[string][]string)
+	for _, validationError := range validationErrors {
+		fieldName := validation.FieldPath(validationError)
+		message := validation.GetValidationMessage(validationError)
+		fieldsWithErrorValue[fieldName] = append(fieldsWithErrorValue[fieldName], message)
+	}
+	return fieldsWithErrorValue
+}
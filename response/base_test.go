@@ -1,14 +1,24 @@
 package response
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/zerpto/ponodo/apperror"
+	"github.com/zerpto/ponodo/timing"
+	"github.com/zerpto/ponodo/validation"
 )
 
 func TestSuccess(t *testing.T) {
@@ -131,3 +141,157 @@ func TestError_ValidationErrors(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "error")
 }
 
+func TestSuccess_ExecutionDuration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(timing.Middleware())
+	router.GET("/", func(ctx *gin.Context) {
+		time.Sleep(5 * time.Millisecond)
+		Ok(ctx, "data")
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var body BaseSuccessResponse[string]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	duration := time.Duration(body.Meta.ExecutionDuration)
+	assert.GreaterOrEqual(t, duration, 5*time.Millisecond)
+
+	header := w.Header().Get(timing.HeaderName)
+	expected := strconv.FormatFloat(float64(duration.Microseconds())/1000, 'f', -1, 64)
+	assert.Equal(t, "total;dur="+expected, header)
+}
+
+func TestError_ValidationErrorAggregation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type Item struct {
+		SKU string `json:"sku" validate:"required"`
+	}
+	type CreateOrder struct {
+		ContactEmail string `json:"contactEmail" validate:"required"`
+		Items        []Item `json:"items" validate:"dive"`
+	}
+	type UpdateOrder struct {
+		ContactEmail string `json:"contactEmail" validate:"email"`
+	}
+
+	v := validation.New()
+	var createErrs, updateErrs validator.ValidationErrors
+	require.ErrorAs(t, v.Struct(CreateOrder{Items: []Item{{SKU: "a"}, {}}}), &createErrs)
+	require.ErrorAs(t, v.Struct(UpdateOrder{ContactEmail: "invalid"}), &updateErrs)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	Error(c, http.StatusUnprocessableEntity, append(createErrs, updateErrs...))
+
+	var body struct {
+		Error map[string][]string `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, map[string][]string{
+		"contactEmail": {
+			"This field is required.",
+			"This field must be a valid email address.",
+		},
+		"items[1].sku": {"This field is required."},
+	}, body.Error)
+}
+
+func TestError_DerivesStatusFromError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type Payload struct {
+		Name string `json:"name" validate:"required"`
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "application error", err: apperror.ErrForbidden, status: http.StatusForbidden},
+		{name: "record not found", err: fmt.Errorf("load: %w", gorm.ErrRecordNotFound), status: http.StatusNotFound},
+		{name: "validation error", err: validation.New().Struct(Payload{}), status: http.StatusUnprocessableEntity},
+		{name: "other error", err: errors.New("boom"), status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			Error(c, 0, tt.err)
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func TestError_ApplicationErrorCause(t *testing.T) {
+	err := apperror.Wrap(errors.New("pq: duplicate key"), apperror.ErrConflict).
+		WithMessage("The email is already taken.").
+		WithDetails(map[string]string{"field": "email"})
+
+	render := func(mode string) map[string]any {
+		gin.SetMode(mode)
+		defer gin.SetMode(gin.TestMode)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		Error(c, 0, err)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body
+	}
+
+	body := render(gin.DebugMode)
+	assert.Equal(t, "conflict", body["code"])
+	assert.Equal(t, map[string]any{"field": "email"}, body["details"])
+	assert.Equal(t, map[string]any{"generic": []any{"The email is already taken."}}, body["error"])
+	assert.Equal(t, "pq: duplicate key", body["cause"])
+
+	body = render(gin.ReleaseMode)
+	assert.NotContains(t, body, "cause")
+	assert.Equal(t, map[string]any{"generic": []any{"The email is already taken."}}, body["error"])
+}
+
+func TestError_ReleaseModeHidesServerErrors(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	defer gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	InternalServerError(c, errors.New("dial tcp 10.0.0.1:5432: connection refused"))
+
+	assert.NotContains(t, w.Body.String(), "10.0.0.1")
+	assert.Contains(t, w.Body.String(), "Internal Server Error")
+}
+
+func TestError_ReleaseModeHidesWrappedErrors(t *testing.T) {
+	err := fmt.Errorf("load order 1 from tenant_42.orders: %w", gorm.ErrRecordNotFound)
+
+	render := func(mode string, status int, err error) string {
+		gin.SetMode(mode)
+		defer gin.SetMode(gin.TestMode)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		Error(c, status, err)
+		return w.Body.String()
+	}
+
+	body := render(gin.ReleaseMode, 0, err)
+	assert.NotContains(t, body, "tenant_42")
+	assert.Contains(t, body, apperror.ErrNotFound.Message)
+
+	body = render(gin.ReleaseMode, http.StatusBadRequest, errors.New("parse tenant_42 filter"))
+	assert.NotContains(t, body, "tenant_42")
+	assert.Contains(t, body, "Bad Request")
+
+	body = render(gin.DebugMode, 0, err)
+	assert.Contains(t, body, "tenant_42.orders: record not found")
+}
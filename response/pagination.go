@@ -0,0 +1,79 @@
+package response
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SuccessPaginated sends a standardized success response for a single page
+// of results. The pagination metadata is included in the response meta and
+// the links to the neighbouring pages are sent in an RFC 8288 Link header.
+func SuccessPaginated[T any](ctx *gin.Context, statusCode int, data T, pagination *MetaPagination) {
+	if link := paginationLinks(ctx, pagination); link != "" {
+		ctx.Header("Link", link)
+	}
+	success(ctx, statusCode, data, pagination)
+}
+
+// OkPaginated sends a 200 OK success response for a single page of results,
+// such as the items and pagination of a repository page.
+func OkPaginated[T any](ctx *gin.Context, data T, pagination *MetaPagination) {
+	SuccessPaginated(ctx, http.StatusOK, data, pagination)
+}
+
+// paginationLinks builds the Link header value for the pagination. Offset
+// pagination links to the first, previous, next and last pages, while
+// cursor pagination links to the previous and next pages. The links reuse
+// the path and query of the current request.
+func paginationLinks(ctx *gin.Context, pagination *MetaPagination) string {
+	if pagination == nil || ctx.Request == nil || ctx.Request.URL == nil {
+		return ""
+	}
+
+	var links []string
+	link := func(rel string, set map[string]string) {
+		query := ctx.Request.URL.Query()
+		query.Set("per_page", strconv.Itoa(pagination.PerPage))
+		for key, value := range set {
+			if value == "" {
+				query.Del(key)
+			} else {
+				query.Set(key, value)
+			}
+		}
+		target := url.URL{Path: ctx.Request.URL.Path, RawQuery: query.Encode()}
+		links = append(links, fmt.Sprintf("<%s>; rel=%q", target.String(), rel))
+	}
+
+	if pagination.Page > 0 {
+		totalPages := 0
+		if pagination.TotalPages != nil {
+			totalPages = *pagination.TotalPages
+		}
+		page := func(page int) map[string]string {
+			return map[string]string{"page": strconv.Itoa(page), "cursor": ""}
+		}
+		link("first", page(1))
+		if pagination.Page > 1 {
+			link("prev", page(min(pagination.Page-1, max(totalPages, 1))))
+		}
+		if pagination.Page < totalPages {
+			link("next", page(pagination.Page+1))
+		}
+		link("last", page(max(totalPages, 1)))
+		return strings.Join(links, ", ")
+	}
+
+	if pagination.PrevCursor != "" {
+		link("prev", map[string]string{"cursor": pagination.PrevCursor, "page": ""})
+	}
+	if pagination.NextCursor != "" {
+		link("next", map[string]string{"cursor": pagination.NextCursor, "page": ""})
+	}
+	return strings.Join(links, ", ")
+}
@@ -0,0 +1,85 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOkPaginated_Offset(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/users?page=2&sort=name", nil)
+
+	total, totalPages := int64(5), 3
+	OkPaginated(c, []string{"a", "b"}, &MetaPagination{Page: 2, PerPage: 2, Total: &total, TotalPages: &totalPages})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `</users?page=1&per_page=2&sort=name>; rel="first", `+
+		`</users?page=1&per_page=2&sort=name>; rel="prev", `+
+		`</users?page=3&per_page=2&sort=name>; rel="next", `+
+		`</users?page=3&per_page=2&sort=name>; rel="last"`, w.Header().Get("Link"))
+
+	var body struct {
+		Meta struct {
+			Pagination map[string]any `json:"pagination"`
+		} `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, map[string]any{
+		"page":        float64(2),
+		"per_page":    float64(2),
+		"total":       float64(5),
+		"total_pages": float64(3),
+	}, body.Meta.Pagination)
+}
+
+func TestOkPaginated_EmptyOffsetPage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/users", nil)
+
+	total, totalPages := int64(0), 0
+	OkPaginated(c, []string{}, &MetaPagination{Page: 1, PerPage: 20, Total: &total, TotalPages: &totalPages})
+
+	assert.Contains(t, w.Body.String(), `"total":0`)
+	assert.Contains(t, w.Body.String(), `"total_pages":0`)
+	assert.Equal(t, `</users?page=1&per_page=20>; rel="first", </users?page=1&per_page=20>; rel="last"`, w.Header().Get("Link"))
+}
+
+func TestOkPaginated_Cursor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/users?cursor=abc", nil)
+
+	OkPaginated(c, []string{"a"}, &MetaPagination{PerPage: 1, NextCursor: "def", PrevCursor: "xyz"})
+
+	assert.Equal(t, `</users?cursor=xyz&per_page=1>; rel="prev", </users?cursor=def&per_page=1>; rel="next"`, w.Header().Get("Link"))
+	assert.Contains(t, w.Body.String(), `"next_cursor":"def"`)
+	assert.NotContains(t, w.Body.String(), `"page"`)
+	assert.NotContains(t, w.Body.String(), `"total"`)
+}
+
+func TestSuccess_WithoutPagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/users", nil)
+
+	Ok(c, "data")
+
+	assert.Empty(t, w.Header().Get("Link"))
+	assert.Contains(t, w.Body.String(), `"pagination":null`)
+}
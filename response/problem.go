@@ -0,0 +1,119 @@
+package response
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemContentType is the media type of RFC 9457 problem details.
+const ProblemContentType = "application/problem+json"
+
+// ErrorFormat selects the envelope used for error responses.
+type ErrorFormat string
+
+const (
+	// ErrorFormatDefault sends errors as a BaseErrorResponse.
+	ErrorFormatDefault ErrorFormat = "default"
+
+	// ErrorFormatProblem sends errors as RFC 9457 problem details.
+	ErrorFormatProblem ErrorFormat = "problem"
+)
+
+// errorFormatKey is the gin context key under which WithErrorFormat stores
+// the error format of the application.
+const errorFormatKey = "ponodo.response.error_format"
+
+// ProblemDetails is an RFC 9457 problem details object. Validation errors
+// are included in the "errors" extension member, grouped by field path in
+// the same way as the default error format. Application errors add their
+// code and details, and their cause outside of release mode.
+type ProblemDetails struct {
+	Type     string              `json:"type"`
+	Title    string              `json:"title"`
+	Status   int                 `json:"status"`
+	Detail   string              `json:"detail,omitempty"`
+	Instance string              `json:"instance,omitempty"`
+	Errors   map[string][]string `json:"errors,omitempty"`
+	Code     string              `json:"code,omitempty"`
+	Details  any                 `json:"details,omitempty"`
+	Cause    string              `json:"cause,omitempty"`
+}
+
+// WithErrorFormat returns a middleware selecting the error format for every
+// route of the router it is installed on. It is the way to opt in to
+// problem details for a whole application.
+func WithErrorFormat(format ErrorFormat) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(errorFormatKey, format)
+		ctx.Next()
+	}
+}
+
+// NegotiateErrorFormat returns the error format for the request. Problem
+// details are used when the application selected them with
+// WithErrorFormat or when the client accepts application/problem+json.
+func NegotiateErrorFormat(ctx *gin.Context) ErrorFormat {
+	if format, ok := ctx.Get(errorFormatKey); ok && format == ErrorFormatProblem {
+		return ErrorFormatProblem
+	}
+	if ctx.Request != nil && acceptsProblem(ctx.Request.Header.Get("Accept")) {
+		return ErrorFormatProblem
+	}
+	return ErrorFormatDefault
+}
+
+// Problem sends the problem details with its status code and the
+// application/problem+json content type. Missing type and title members
+// default to "about:blank" and the status text.
+func Problem(ctx *gin.Context, problem *ProblemDetails) {
+	if problem.Status == 0 {
+		problem.Status = http.StatusInternalServerError
+	}
+	if problem.Type == "" {
+		problem.Type = "about:blank"
+	}
+	if problem.Title == "" {
+		problem.Title = http.StatusText(problem.Status)
+	}
+	if problem.Instance == "" && ctx.Request != nil && ctx.Request.URL != nil {
+		problem.Instance = ctx.Request.URL.Path
+	}
+
+	ctx.Header("Content-Type", ProblemContentType)
+	ctx.JSON(problem.Status, problem)
+	ctx.Abort()
+}
+
+// problem sends the error content as problem details.
+func problem(ctx *gin.Context, statusCode int, content errorContent) {
+	Problem(ctx, &ProblemDetails{
+		Status:  statusCode,
+		Detail:  content.message,
+		Errors:  content.fields,
+		Code:    content.code,
+		Details: content.details,
+		Cause:   content.cause,
+	})
+}
+
+// acceptsProblem reports whether the Accept header lists the problem
+// details media type with a non-zero quality.
+func acceptsProblem(accept string) bool {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil || mediaType != ProblemContentType {
+			continue
+		}
+		if q, ok := params["q"]; ok {
+			if quality, err := strconv.ParseFloat(q, 64); err == nil && quality == 0 {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
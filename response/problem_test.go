@@ -0,0 +1,87 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zerpto/ponodo/validation"
+)
+
+func TestError_ProblemDetailsPerApp(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(WithErrorFormat(ErrorFormatProblem))
+	router.GET("/orders/:id", func(ctx *gin.Context) {
+		NotFound(ctx, errors.New("order 42 does not exist"))
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders/42", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Not Found",
+		"status": 404,
+		"detail": "order 42 does not exist",
+		"instance": "/orders/42"
+	}`, w.Body.String())
+}
+
+func TestError_ProblemDetailsNegotiated(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type CreateOrder struct {
+		Email string `json:"email" validate:"required"`
+	}
+	err := validation.New().Struct(CreateOrder{})
+	require.Error(t, err)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders", nil)
+	c.Request.Header.Set("Accept", "application/json;q=0.9, application/problem+json")
+
+	BadRequest(c, err)
+
+	var problem ProblemDetails
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, http.StatusBadRequest, problem.Status)
+	assert.Equal(t, "Bad Request", problem.Title)
+	assert.Equal(t, map[string][]string{"email": {"This field is required."}}, problem.Errors)
+}
+
+func TestNegotiateErrorFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		accept   string
+		expected ErrorFormat
+	}{
+		{name: "no accept header", accept: "", expected: ErrorFormatDefault},
+		{name: "json", accept: "application/json", expected: ErrorFormatDefault},
+		{name: "problem", accept: "application/problem+json", expected: ErrorFormatProblem},
+		{name: "problem refused", accept: "application/problem+json;q=0, application/json", expected: ErrorFormatDefault},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			c.Request.Header.Set("Accept", tt.accept)
+
+			assert.Equal(t, tt.expected, NegotiateErrorFormat(c))
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package timing
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware records the start time of every request so that response
+// helpers can report the real handler duration. The recorded sub-timings
+// and the total duration are sent in the Server-Timing header. It should
+// be the first middleware of the router to cover the whole request.
+func Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		timer := NewTimer(time.Now())
+		ctx.Set(contextKey, timer)
+		ctx.Request = ctx.Request.WithContext(WithTimer(ctx.Request.Context(), timer))
+
+		writer := &timingWriter{ResponseWriter: ctx.Writer, timer: timer}
+		ctx.Writer = writer
+
+		ctx.Next()
+
+		// Responses without a body are only written after the handler chain
+		// returns, so the header is still unsent here.
+		writer.setHeader()
+	}
+}
+
+// timingWriter adds the Server-Timing header right before the response
+// headers are sent.
+type timingWriter struct {
+	gin.ResponseWriter
+	timer *Timer
+	done  bool
+}
+
+func (w *timingWriter) setHeader() {
+	if w.done || w.ResponseWriter.Written() {
+		return
+	}
+	w.done = true
+	w.Header().Set(HeaderName, w.timer.Header())
+}
+
+// WriteHeaderNow sends the Server-Timing header with the response headers.
+func (w *timingWriter) WriteHeaderNow() {
+	w.setHeader()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// Write sends the Server-Timing header with the response headers before
+// writing the body.
+func (w *timingWriter) Write(data []byte) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString sends the Server-Timing header with the response headers
+// before writing the body.
+func (w *timingWriter) WriteString(s string) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Flush sends the Server-Timing header before flushing a streamed response.
+func (w *timingWriter) Flush() {
+	w.setHeader()
+	w.ResponseWriter.Flush()
+}
@@ -0,0 +1,36 @@
+package timing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(Middleware())
+	router.GET("/json", func(ctx *gin.Context) {
+		Record(ctx, "db", 2*time.Millisecond)
+		Record(ctx.Request.Context(), "upstream", 3*time.Millisecond)
+		ctx.JSON(http.StatusOK, gin.H{"elapsed": Finish(ctx) > 0})
+	})
+	router.GET("/empty", func(ctx *gin.Context) {
+		ctx.Status(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/json", nil))
+	assert.Regexp(t, `^db;dur=2, upstream;dur=3, total;dur=[0-9.]+$`, w.Header().Get(HeaderName))
+	assert.JSONEq(t, `{"elapsed": true}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/empty", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Regexp(t, `^total;dur=[0-9.]+$`, w.Header().Get(HeaderName))
+}
@@ -0,0 +1,189 @@
+package timing
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HeaderName is the response header carrying the recorded timings.
+const HeaderName = "Server-Timing"
+
+// TotalMetric is the name of the Server-Timing metric holding the total
+// handler duration.
+const TotalMetric = "total"
+
+// contextKey is the gin context key and context.Context key under which the
+// middleware stores the request timer.
+const contextKey = "ponodo.timing"
+
+type timerKey struct{}
+
+// Metric is a named sub-timing of a request, such as a database query or a
+// call to an upstream service.
+type Metric struct {
+	Name        string
+	Description string
+	Duration    time.Duration
+}
+
+// Timer records the start time of a request together with its named
+// sub-timings. It is safe for concurrent use, so handlers may record
+// timings from several goroutines.
+type Timer struct {
+	start time.Time
+
+	mu      sync.Mutex
+	end     time.Time
+	metrics []Metric
+}
+
+// NewTimer creates a timer started at the given time.
+func NewTimer(start time.Time) *Timer {
+	return &Timer{start: start}
+}
+
+// Start returns the time the request started.
+func (t *Timer) Start() time.Time {
+	return t.start
+}
+
+// Elapsed returns the time since the request started, or the total
+// duration once the timer has been finished.
+func (t *Timer) Elapsed() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.end.IsZero() {
+		return t.end.Sub(t.start)
+	}
+	return time.Since(t.start)
+}
+
+// Finish stops the timer and returns the total duration. Later calls return
+// the same duration, so the response body and the Server-Timing header
+// report the same value.
+func (t *Timer) Finish() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.end.IsZero() {
+		t.end = time.Now()
+	}
+	return t.end.Sub(t.start)
+}
+
+// Record adds a named sub-timing with an optional description.
+func (t *Timer) Record(name string, duration time.Duration, description ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.metrics = append(t.metrics, Metric{
+		Name:        name,
+		Description: strings.Join(description, " "),
+		Duration:    duration,
+	})
+}
+
+// Metrics returns the recorded sub-timings in the order they were recorded.
+func (t *Timer) Metrics() []Metric {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	metrics := make([]Metric, len(t.metrics))
+	copy(metrics, t.metrics)
+	return metrics
+}
+
+// Header formats the sub-timings followed by the total duration as a
+// Server-Timing header value, for example
+// `db;dur=12.5;desc="load user", total;dur=20.1`. Durations are in
+// milliseconds as required by the specification.
+func (t *Timer) Header() string {
+	metrics := append(t.Metrics(), Metric{Name: TotalMetric, Duration: t.Finish()})
+
+	entries := make([]string, 0, len(metrics))
+	for _, metric := range metrics {
+		entry := sanitizeName(metric.Name) + ";dur=" + strconv.FormatFloat(float64(metric.Duration.Microseconds())/1000, 'f', -1, 64)
+		if metric.Description != "" {
+			entry += ";desc=" + strconv.Quote(metric.Description)
+		}
+		entries = append(entries, entry)
+	}
+	return strings.Join(entries, ", ")
+}
+
+// FromContext returns the timer stored by Middleware. It accepts either
+// the *gin.Context of the request or its request context, so code below
+// the HTTP layer can record timings too. It returns nil when the
+// middleware is not installed.
+func FromContext(ctx context.Context) *Timer {
+	if ginCtx, ok := ctx.(*gin.Context); ok {
+		if value, exists := ginCtx.Get(contextKey); exists {
+			timer, _ := value.(*Timer)
+			return timer
+		}
+		if ginCtx.Request == nil {
+			return nil
+		}
+		ctx = ginCtx.Request.Context()
+	}
+	timer, _ := ctx.Value(timerKey{}).(*Timer)
+	return timer
+}
+
+// WithTimer returns a copy of the context carrying the timer.
+func WithTimer(ctx context.Context, timer *Timer) context.Context {
+	return context.WithValue(ctx, timerKey{}, timer)
+}
+
+// Record adds a named sub-timing to the request timer in the context. It
+// does nothing when the timing middleware is not installed.
+func Record(ctx context.Context, name string, duration time.Duration, description ...string) {
+	if timer := FromContext(ctx); timer != nil {
+		timer.Record(name, duration, description...)
+	}
+}
+
+// Track starts a named sub-timing and returns the function that records
+// it, which is meant to be deferred:
+//
+//	defer timing.Track(ctx, "db", "load user")()
+func Track(ctx context.Context, name string, description ...string) func() {
+	start := time.Now()
+	return func() {
+		Record(ctx, name, time.Since(start), description...)
+	}
+}
+
+// Elapsed returns the time since the request started. It returns zero
+// when the timing middleware is not installed.
+func Elapsed(ctx context.Context) time.Duration {
+	if timer := FromContext(ctx); timer != nil {
+		return timer.Elapsed()
+	}
+	return 0
+}
+
+// Finish stops the request timer and returns the total handler duration.
+// It returns zero when the timing middleware is not installed.
+func Finish(ctx context.Context) time.Duration {
+	if timer := FromContext(ctx); timer != nil {
+		return timer.Finish()
+	}
+	return 0
+}
+
+// sanitizeName replaces the characters that are not allowed in a
+// Server-Timing metric name.
+func sanitizeName(name string) string {
+	if name == "" {
+		return "unnamed"
+	}
+	return strings.Map(func(r rune) rune {
+		if r > 0x20 && r < 0x7f && !strings.ContainsRune(`"(),/:;<=>?@[\]{}`, r) {
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,53 @@
+package timing
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimer_Header(t *testing.T) {
+	timer := NewTimer(time.Now().Add(-20 * time.Millisecond))
+	timer.Record("db", 12500*time.Microsecond, "load user")
+	timer.Record("cache miss", time.Millisecond)
+
+	header := timer.Header()
+	assert.True(t, strings.HasPrefix(header, `db;dur=12.5;desc="load user", cache_miss;dur=1, total;dur=`), header)
+}
+
+func TestTimer_Finish(t *testing.T) {
+	timer := NewTimer(time.Now())
+	total := timer.Finish()
+	time.Sleep(time.Millisecond)
+
+	assert.Equal(t, total, timer.Finish())
+	assert.Equal(t, total, timer.Elapsed())
+}
+
+func TestContextHelpers(t *testing.T) {
+	timer := NewTimer(time.Now())
+	ctx := WithTimer(context.Background(), timer)
+
+	done := Track(ctx, "upstream")
+	done()
+	Record(ctx, "db", time.Millisecond)
+
+	metrics := timer.Metrics()
+	assert.Len(t, metrics, 2)
+	assert.Equal(t, "upstream", metrics[0].Name)
+	assert.Equal(t, "db", metrics[1].Name)
+}
+
+func TestContextHelpers_WithoutMiddleware(t *testing.T) {
+	ctx := context.Background()
+
+	assert.NotPanics(t, func() {
+		Record(ctx, "db", time.Millisecond)
+		Track(ctx, "db")()
+	})
+	assert.Zero(t, Elapsed(ctx))
+	assert.Zero(t, Finish(ctx))
+}
@@ -0,0 +1,85 @@
+package ponodo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/zerpto/ponodo/contracts"
+	"github.com/zerpto/ponodo/tracing"
+)
+
+// tracingShutdownTimeout bounds the time spent flushing buffered spans when
+// the application shuts down.
+const tracingShutdownTimeout = 5 * time.Second
+
+// TracingServiceProvider is the built-in service provider that sets up
+// OpenTelemetry tracing from the TRACING_* configuration. It is registered
+// by NewApp under the name "tracing". The W3C trace context propagator is
+// always installed, while spans are only recorded and exported when an
+// exporter is configured.
+type TracingServiceProvider struct {
+	tracerProvider *sdktrace.TracerProvider
+}
+
+// Name returns the name the tracing provider is registered under.
+func (p *TracingServiceProvider) Name() string {
+	return "tracing"
+}
+
+// DependsOn returns the providers that must be registered before tracing.
+func (p *TracingServiceProvider) DependsOn() []string {
+	return nil
+}
+
+// Register creates the tracer provider configured by TRACING_EXPORTER,
+// installs it as the global OpenTelemetry tracer provider and binds it into
+// the application container. Tracing stays disabled when no configuration
+// has been loaded or no exporter is set.
+func (p *TracingServiceProvider) Register(app contracts.AppContract) error {
+	otel.SetTextMapPropagator(tracing.Propagator())
+
+	loader := app.GetConfigLoader()
+	if loader == nil {
+		return nil
+	}
+	tracingConfig, err := loader.GetTracingConfig()
+	if err != nil {
+		return err
+	}
+	if !tracingConfig.Enabled() {
+		return nil
+	}
+
+	tracerProvider, err := tracing.NewTracerProvider(context.Background(), tracingConfig)
+	if err != nil {
+		return fmt.Errorf("tracing: %w", err)
+	}
+	p.tracerProvider = tracerProvider
+	otel.SetTracerProvider(tracerProvider)
+	Instance(app, tracerProvider)
+	return nil
+}
+
+// Boot is a no-op for the tracing provider.
+func (p *TracingServiceProvider) Boot(app contracts.AppContract) error {
+	return nil
+}
+
+// Shutdown flushes the buffered spans and stops the exporter.
+func (p *TracingServiceProvider) Shutdown(app contracts.AppContract) error {
+	if p.tracerProvider == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+	return p.tracerProvider.Shutdown(ctx)
+}
+
+// NewTracingServiceProvider creates the built-in tracing service provider.
+func NewTracingServiceProvider() contracts.ServiceProviderContract {
+	return &TracingServiceProvider{}
+}
@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// GormPluginName is the name GormPlugin is registered under on a GORM
+// connection.
+const GormPluginName = "ponodo:tracing"
+
+const spanKey = "ponodo:tracing:span"
+
+// GormPlugin creates a client span for every query run with a context that
+// carries a span, such as db.WithContext(ctx.Request.Context()) in an HTTP
+// handler. Queries without a parent span are not traced, so migrations and
+// background work do not produce a trace per query. Record not found
+// errors do not mark the span as failed.
+type GormPlugin struct {
+}
+
+// NewGormPlugin creates the tracing plugin.
+func NewGormPlugin() *GormPlugin {
+	return &GormPlugin{}
+}
+
+// Name returns the name of the plugin.
+func (p *GormPlugin) Name() string {
+	return GormPluginName
+}
+
+// Initialize registers the plugin callbacks around every GORM operation.
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("gorm:create").Register(GormPluginName+":before_create", p.before("create")),
+		callbacks.Create().After("gorm:create").Register(GormPluginName+":after_create", p.after),
+		callbacks.Query().Before("gorm:query").Register(GormPluginName+":before_query", p.before("query")),
+		callbacks.Query().After("gorm:query").Register(GormPluginName+":after_query", p.after),
+		callbacks.Update().Before("gorm:update").Register(GormPluginName+":before_update", p.before("update")),
+		callbacks.Update().After("gorm:update").Register(GormPluginName+":after_update", p.after),
+		callbacks.Delete().Before("gorm:delete").Register(GormPluginName+":before_delete", p.before("delete")),
+		callbacks.Delete().After("gorm:delete").Register(GormPluginName+":after_delete", p.after),
+		callbacks.Row().Before("gorm:row").Register(GormPluginName+":before_row", p.before("row")),
+		callbacks.Row().After("gorm:row").Register(GormPluginName+":after_row", p.after),
+		callbacks.Raw().Before("gorm:raw").Register(GormPluginName+":before_raw", p.before("raw")),
+		callbacks.Raw().After("gorm:raw").Register(GormPluginName+":after_raw", p.after),
+	)
+}
+
+func (p *GormPlugin) before(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+			return
+		}
+
+		_, span := Tracer().Start(ctx, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemNameKey.String(db.Dialector.Name()),
+				semconv.DBOperationName(operation),
+			),
+		)
+		db.InstanceSet(spanKey, span)
+	}
+}
+
+func (p *GormPlugin) after(db *gorm.DB) {
+	value, ok := db.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+	if db.Statement.Table != "" {
+		span.SetAttributes(semconv.DBCollectionName(db.Statement.Table))
+	}
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type tracingTestRecord struct {
+	ID   uint
+	Name string
+}
+
+func newTracedDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "tracing.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(NewGormPlugin()))
+	require.NoError(t, db.AutoMigrate(&tracingTestRecord{}))
+	return db
+}
+
+func TestGormPlugin(t *testing.T) {
+	recorder := newRecorder(t)
+	db := newTracedDB(t)
+
+	ctx, parent := Tracer().Start(context.Background(), "GET /records")
+	require.NoError(t, db.WithContext(ctx).Create(&tracingTestRecord{Name: "first"}).Error)
+	var record tracingTestRecord
+	assert.ErrorIs(t, db.WithContext(ctx).First(&record, 42).Error, gorm.ErrRecordNotFound)
+	assert.Error(t, db.WithContext(ctx).Exec("SELECT * FROM missing_table").Error)
+	parent.End()
+
+	var children []string
+	for _, span := range recorder.Ended() {
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			continue
+		}
+		children = append(children, span.Name())
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+
+		switch span.Name() {
+		case "gorm.query":
+			assert.Equal(t, codes.Unset, span.Status().Code)
+		case "gorm.raw":
+			assert.Equal(t, codes.Error, span.Status().Code)
+		}
+	}
+	assert.Equal(t, []string{"gorm.create", "gorm.query", "gorm.raw"}, children)
+}
+
+func TestGormPlugin_WithoutParentSpan(t *testing.T) {
+	recorder := newRecorder(t)
+	db := newTracedDB(t)
+
+	require.NoError(t, db.Create(&tracingTestRecord{Name: "first"}).Error)
+	assert.Empty(t, recorder.Ended())
+}
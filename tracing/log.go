@@ -0,0 +1,29 @@
+package tracing
+
+import (
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Field names of the trace and span IDs added to log entries.
+const (
+	TraceIDKey = "trace_id"
+	SpanIDKey  = "span_id"
+)
+
+// LogHook adds the trace and span IDs of the span carried by the context of
+// a log entry, set with Ctx, so that logs can be correlated with traces:
+//
+//	log.Info().Ctx(ctx.Request.Context()).Msg("order placed")
+type LogHook struct {
+}
+
+// Run implements zerolog.Hook.
+func (h LogHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	spanContext := trace.SpanContextFromContext(e.GetCtx())
+	if !spanContext.IsValid() {
+		return
+	}
+	e.Str(TraceIDKey, spanContext.TraceID().String()).
+		Str(SpanIDKey, spanContext.SpanID().String())
+}
@@ -0,0 +1,37 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogHook(t *testing.T) {
+	newRecorder(t)
+	out := &bytes.Buffer{}
+	logger := zerolog.New(out).Hook(LogHook{})
+
+	ctx, span := Tracer().Start(context.Background(), "place order")
+	defer span.End()
+	logger.Info().Ctx(ctx).Msg("order placed")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entry))
+	assert.Equal(t, span.SpanContext().TraceID().String(), entry[TraceIDKey])
+	assert.Equal(t, span.SpanContext().SpanID().String(), entry[SpanIDKey])
+}
+
+func TestLogHook_WithoutSpan(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger := zerolog.New(out).Hook(LogHook{})
+
+	logger.Info().Ctx(context.Background()).Msg("started")
+	logger.Info().Msg("started")
+
+	assert.NotContains(t, out.String(), TraceIDKey)
+}
@@ -0,0 +1,104 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/zerpto/ponodo/config"
+)
+
+// TracerName is the instrumentation name of the spans created by the
+// framework.
+const TracerName = "github.com/zerpto/ponodo"
+
+// Tracer returns the framework tracer from the global tracer provider. It
+// creates non-recording spans until a provider is installed, so
+// instrumented code needs no checks for whether tracing is enabled.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// Propagator returns the W3C trace context and baggage propagator used to
+// read and write the traceparent, tracestate and baggage headers.
+func Propagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+}
+
+// NewTracerProvider creates a tracer provider batching spans to the
+// exporter named in the configuration. Spans are sampled by trace ID with
+// the configured ratio unless the parent span has already been sampled.
+// The provider must be shut down to flush the remaining spans.
+func NewTracerProvider(ctx context.Context, tracingConfig *config.TracingConfig) (*sdktrace.TracerProvider, error) {
+	exporter, err := newExporter(ctx, tracingConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(tracingConfig.ServiceName),
+	))
+	if err != nil {
+		_ = exporter.Shutdown(ctx)
+		return nil, fmt.Errorf("tracing resource: %w", err)
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(tracingConfig.SampleRatio))),
+	), nil
+}
+
+func newExporter(ctx context.Context, tracingConfig *config.TracingConfig) (sdktrace.SpanExporter, error) {
+	switch tracingConfig.Exporter {
+	case config.TracingExporterOTLP:
+		var options []otlptracehttp.Option
+		if tracingConfig.Endpoint != "" {
+			options = append(options, otlptracehttp.WithEndpoint(tracingConfig.Endpoint))
+		}
+		if tracingConfig.Insecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, options...)
+	case config.TracingExporterStdout:
+		return stdouttrace.New()
+	case config.TracingExporterFile:
+		file, err := os.OpenFile(tracingConfig.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("open trace file: %w", err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+		return &fileExporter{SpanExporter: exporter, file: file}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown trace exporter %q", config.ErrConfigInvalid, tracingConfig.Exporter)
+	}
+}
+
+// fileExporter closes the trace file once the exporter has been shut down.
+type fileExporter struct {
+	sdktrace.SpanExporter
+	file *os.File
+}
+
+func (e *fileExporter) Shutdown(ctx context.Context) error {
+	err := e.SpanExporter.Shutdown(ctx)
+	if closeErr := e.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
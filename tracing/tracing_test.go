@@ -0,0 +1,90 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/zerpto/ponodo/config"
+)
+
+// newRecorder installs a tracer provider recording every span as the global
+// provider for the duration of the test.
+func newRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		_ = provider.Shutdown(context.Background())
+	})
+	return recorder
+}
+
+func TestNewTracerProvider_File(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "traces.json")
+	provider, err := NewTracerProvider(context.Background(), &config.TracingConfig{
+		Exporter:    config.TracingExporterFile,
+		ServiceName: "orders",
+		SampleRatio: 1,
+		File:        file,
+	})
+	require.NoError(t, err)
+
+	_, span := provider.Tracer(TracerName).Start(context.Background(), "place order")
+	span.End()
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"Name":"place order"`)
+	assert.Contains(t, string(content), `"Value":"orders"`)
+}
+
+func TestNewTracerProvider_NotSampled(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "traces.json")
+	provider, err := NewTracerProvider(context.Background(), &config.TracingConfig{
+		Exporter:    config.TracingExporterFile,
+		SampleRatio: 0,
+		File:        file,
+	})
+	require.NoError(t, err)
+
+	_, span := provider.Tracer(TracerName).Start(context.Background(), "place order")
+	span.End()
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Empty(t, content)
+}
+
+func TestNewTracerProvider_UnknownExporter(t *testing.T) {
+	_, err := NewTracerProvider(context.Background(), &config.TracingConfig{Exporter: "jaeger"})
+	assert.True(t, errors.Is(err, config.ErrConfigInvalid))
+}
+
+func TestNewTracerProvider_OTLP(t *testing.T) {
+	provider, err := NewTracerProvider(context.Background(), &config.TracingConfig{
+		Exporter:    config.TracingExporterOTLP,
+		Endpoint:    "127.0.0.1:4318",
+		Insecure:    true,
+		SampleRatio: 1,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = provider.Shutdown(ctx)
+}
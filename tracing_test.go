@@ -0,0 +1,67 @@
+package ponodo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/zerpto/ponodo/config"
+	"github.com/zerpto/ponodo/tracing"
+)
+
+func TestTracingServiceProvider(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	file := filepath.Join(t.TempDir(), "traces.json")
+	viper.Set("tracing_exporter", "file")
+	viper.Set("tracing_file", file)
+	viper.Set("tracing_service_name", "orders")
+
+	previous := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(previous)
+
+	app := &App{ConfigLoader: &config.Loader{}}
+	provider := NewTracingServiceProvider()
+	require.NoError(t, provider.Register(app))
+
+	tracerProvider, err := Make[*sdktrace.TracerProvider](app)
+	require.NoError(t, err)
+	assert.Same(t, tracerProvider, otel.GetTracerProvider())
+
+	_, span := tracing.Tracer().Start(t.Context(), "place order")
+	span.End()
+	require.NoError(t, provider.Shutdown(app))
+
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"Name":"place order"`)
+}
+
+func TestTracingServiceProvider_Disabled(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	previous := otel.GetTracerProvider()
+	app := &App{ConfigLoader: &config.Loader{}}
+	provider := NewTracingServiceProvider()
+	require.NoError(t, provider.Register(app))
+	require.NoError(t, provider.Shutdown(app))
+
+	assert.Equal(t, previous, otel.GetTracerProvider())
+}
+
+func TestTracingServiceProvider_InvalidConfig(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("tracing_exporter", "jaeger")
+
+	app := &App{ConfigLoader: &config.Loader{}}
+	err := NewTracingServiceProvider().Register(app)
+	assert.ErrorIs(t, err, config.ErrConfigInvalid)
+}
@@ -0,0 +1,49 @@
+package ponodo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/zerpto/ponodo/contracts"
+	"github.com/zerpto/ponodo/txcontext"
+	"gorm.io/gorm"
+)
+
+// WithTransaction runs fn inside a transaction on the default database
+// connection. The transaction is stored in the context passed to fn, so
+// code calling DbFromContext with that context joins it without the
+// transaction being threaded through every function signature. When the
+// context already carries a transaction, the nested call runs inside a
+// savepoint that is rolled back on its own if fn fails. The transaction is
+// committed when fn returns nil and rolled back when it returns an error
+// or panics.
+func WithTransaction(ctx context.Context, app contracts.AppContract, fn func(ctx context.Context) error, opts ...*sql.TxOptions) error {
+	db := DbFromContext(ctx, app)
+	if db == nil {
+		return fmt.Errorf("%w: %q", ErrConnectionNotFound, DefaultConnection)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		return fn(txcontext.With(ctx, tx))
+	}, opts...)
+}
+
+// DbFromContext returns the transaction stored in the context by
+// WithTransaction. Outside of a transaction it falls back to the default
+// database connection of the application. The returned connection is bound
+// to ctx so that cancellation and deadlines apply to its queries. It
+// returns nil when neither is available.
+func DbFromContext(ctx context.Context, app contracts.AppContract) *gorm.DB {
+	if tx := txcontext.From(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	if app == nil {
+		return nil
+	}
+	db := app.GetDb()
+	if db == nil {
+		return nil
+	}
+	return db.WithContext(ctx)
+}
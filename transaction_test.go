@@ -0,0 +1,98 @@
+package ponodo
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type txTestAccount struct {
+	ID   uint
+	Name string
+}
+
+func newTxTestApp(t *testing.T) *App {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "tx.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&txTestAccount{}))
+	return &App{DB: db}
+}
+
+func createAccount(ctx context.Context, app *App, name string) error {
+	return DbFromContext(ctx, app).Create(&txTestAccount{Name: name}).Error
+}
+
+func accountNames(t *testing.T, app *App) []string {
+	t.Helper()
+	var names []string
+	require.NoError(t, app.DB.Model(&txTestAccount{}).Order("id").Pluck("name", &names).Error)
+	return names
+}
+
+func TestWithTransaction_Commit(t *testing.T) {
+	app := newTxTestApp(t)
+
+	err := WithTransaction(context.Background(), app, func(ctx context.Context) error {
+		return createAccount(ctx, app, "alice")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice"}, accountNames(t, app))
+}
+
+func TestWithTransaction_Rollback(t *testing.T) {
+	app := newTxTestApp(t)
+	boom := errors.New("boom")
+
+	err := WithTransaction(context.Background(), app, func(ctx context.Context) error {
+		require.NoError(t, createAccount(ctx, app, "alice"))
+		return boom
+	})
+	assert.ErrorIs(t, err, boom)
+	assert.Empty(t, accountNames(t, app))
+}
+
+func TestWithTransaction_NestedSavepoint(t *testing.T) {
+	app := newTxTestApp(t)
+
+	err := WithTransaction(context.Background(), app, func(ctx context.Context) error {
+		outer := DbFromContext(ctx, app)
+		require.NoError(t, createAccount(ctx, app, "alice"))
+
+		nestedErr := WithTransaction(ctx, app, func(ctx context.Context) error {
+			assert.Equal(t, outer.Statement.ConnPool, DbFromContext(ctx, app).Statement.ConnPool)
+			require.NoError(t, createAccount(ctx, app, "bob"))
+			return errors.New("rollback to savepoint")
+		})
+		assert.Error(t, nestedErr)
+
+		return WithTransaction(ctx, app, func(ctx context.Context) error {
+			return createAccount(ctx, app, "carol")
+		})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "carol"}, accountNames(t, app))
+}
+
+func TestDbFromContext_FallsBackToApp(t *testing.T) {
+	app := newTxTestApp(t)
+
+	db := DbFromContext(context.Background(), app)
+	require.NotNil(t, db)
+	assert.Equal(t, app.DB.Statement.ConnPool, db.Statement.ConnPool)
+
+	assert.Nil(t, DbFromContext(context.Background(), &App{}))
+}
+
+func TestWithTransaction_NoDatabase(t *testing.T) {
+	err := WithTransaction(context.Background(), &App{}, func(ctx context.Context) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrConnectionNotFound)
+}
@@ -0,0 +1,25 @@
+package txcontext
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// contextKey is the context key under which the active transaction is
+// stored.
+type contextKey struct{}
+
+// With returns a copy of ctx carrying tx as the active transaction. It is
+// used by ponodo.WithTransaction and by commands that open a transaction
+// themselves, such as db:seed, so that ponodo.DbFromContext joins it.
+func With(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, contextKey{}, tx)
+}
+
+// From returns the transaction stored in ctx by With, or nil when there is
+// none.
+func From(ctx context.Context) *gorm.DB {
+	tx, _ := ctx.Value(contextKey{}).(*gorm.DB)
+	return tx
+}
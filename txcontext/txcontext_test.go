@@ -0,0 +1,17 @@
+package txcontext
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestWith(t *testing.T) {
+	ctx := context.Background()
+	assert.Nil(t, From(ctx))
+
+	tx := &gorm.DB{}
+	assert.Same(t, tx, From(With(ctx, tx)))
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortByDependencies(t *testing.T) {
+	tests := []struct {
+		name         string
+		names        []string
+		dependencies map[string][]string
+		expected     []string
+		expectError  bool
+	}{
+		{
+			name:     "no dependencies keeps order",
+			names:    []string{"logger", "database", "validator"},
+			expected: []string{"logger", "database", "validator"},
+		},
+		{
+			name:  "dependency moved before dependant",
+			names: []string{"cache", "database", "logger"},
+			dependencies: map[string][]string{
+				"cache":    {"database"},
+				"database": {"logger"},
+			},
+			expected: []string{"logger", "database", "cache"},
+		},
+		{
+			name:  "unknown dependency",
+			names: []string{"cache"},
+			dependencies: map[string][]string{
+				"cache": {"redis"},
+			},
+			expectError: true,
+		},
+		{
+			name:  "cycle",
+			names: []string{"a", "b"},
+			dependencies: map[string][]string{
+				"a": {"b"},
+				"b": {"a"},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := SortByDependencies(tt.names, tt.dependencies)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/go-playground/validator/v10"
-	"github.com/zerpto/ponodo/utils"
 )
 
 // Validation message map for user-friendly error messages
@@ -41,10 +40,11 @@ var validationMessages = map[string]string{
 }
 
 // GetValidationMessage returns a user-friendly validation error message.
-// It formats validation errors by converting field names to snake_case
-// and applying appropriate error messages based on the validation tag.
+// It formats validation errors by referring to the field by its path, as
+// returned by FieldPath, and applying appropriate error messages based on
+// the validation tag.
 func GetValidationMessage(err validator.FieldError) string {
-	fieldName := utils.ToSnakeCase(err.Field())
+	fieldName := FieldPath(err)
 	tag := err.Tag()
 	param := err.Param()
 
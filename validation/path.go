@@ -0,0 +1,64 @@
+package validation
+
+import (
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/zerpto/ponodo/utils"
+)
+
+// FieldPath returns the path of the field that failed validation relative
+// to the validated struct, such as "items[2].sku" for the SKU field of the
+// third element of Items. Segments named through a tag name function, such
+// as the json tag registered by New, are kept as is, while Go field names
+// are converted to snake_case.
+func FieldPath(err validator.FieldError) string {
+	segments := splitNamespace(err.Namespace())
+	structSegments := splitNamespace(err.StructNamespace())
+	if len(segments) < 2 || len(segments) != len(structSegments) {
+		return utils.ToSnakeCase(err.Field())
+	}
+
+	// The first segment is the name of the validated struct type.
+	segments, structSegments = segments[1:], structSegments[1:]
+	for i, segment := range segments {
+		if segment == structSegments[i] {
+			name, index := splitIndex(segment)
+			segments[i] = utils.ToSnakeCase(name) + index
+		}
+	}
+	return strings.Join(segments, ".")
+}
+
+// splitNamespace splits a validator namespace on the dots that separate
+// fields, leaving dots inside map keys such as Labels[a.b] untouched.
+func splitNamespace(namespace string) []string {
+	if namespace == "" {
+		return nil
+	}
+
+	var segments []string
+	depth, start := 0, 0
+	for i, r := range namespace {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '.':
+			if depth == 0 {
+				segments = append(segments, namespace[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(segments, namespace[start:])
+}
+
+// splitIndex separates a field name from its slice index or map key.
+func splitIndex(segment string) (string, string) {
+	if i := strings.IndexByte(segment, '['); i >= 0 {
+		return segment[:i], segment[i:]
+	}
+	return segment, ""
+}
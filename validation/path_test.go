@@ -0,0 +1,64 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	SKU      string `json:"sku" validate:"required"`
+	Quantity int    `validate:"gte=1"`
+}
+
+type testAddress struct {
+	PostCode string `json:"postCode" validate:"required"`
+}
+
+type testOrder struct {
+	CustomerName string            `validate:"required"`
+	Items        []testItem        `json:"items" validate:"dive"`
+	Address      testAddress       `json:"address"`
+	Labels       map[string]string `json:"labels" validate:"dive,required"`
+}
+
+func fieldPaths(t *testing.T, v *validator.Validate, value any) []string {
+	t.Helper()
+	var errs validator.ValidationErrors
+	require.ErrorAs(t, v.Struct(value), &errs)
+
+	paths := make([]string, len(errs))
+	for i, err := range errs {
+		paths[i] = FieldPath(err)
+	}
+	return paths
+}
+
+func TestFieldPath(t *testing.T) {
+	order := testOrder{
+		Items:  []testItem{{SKU: "a", Quantity: 1}, {SKU: "b", Quantity: 1}, {Quantity: 0}},
+		Labels: map[string]string{"a.b": ""},
+	}
+
+	assert.ElementsMatch(t, []string{
+		"customer_name",
+		"items[2].sku",
+		"items[2].quantity",
+		"address.postCode",
+		"labels[a.b]",
+	}, fieldPaths(t, New(), order))
+
+	assert.ElementsMatch(t, []string{
+		"customer_name",
+		"items[2].sku",
+		"items[2].quantity",
+		"address.post_code",
+		"labels[a.b]",
+	}, fieldPaths(t, validator.New(), order))
+}
+
+func TestFieldPath_WithoutNamespace(t *testing.T) {
+	assert.Equal(t, "email_address", FieldPath(mockFieldError{field: "EmailAddress"}))
+}
@@ -0,0 +1,28 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// New creates the validator used by the framework. Required structs are
+// validated and field names are taken from the json tag, so validation
+// errors refer to fields by the names clients send.
+func New() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(JSONTagName)
+	return v
+}
+
+// JSONTagName returns the name of the field in its json tag. It returns an
+// empty string, which makes the validator fall back to the Go field name,
+// for fields without a json name or excluded with "-".
+func JSONTagName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,23 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONTagName(t *testing.T) {
+	type sample struct {
+		Named     string `json:"named_field,omitempty"`
+		Untagged  string
+		Excluded  string `json:"-"`
+		OnlyFlags string `json:",omitempty"`
+	}
+
+	typ := reflect.TypeOf(sample{})
+	assert.Equal(t, "named_field", JSONTagName(typ.Field(0)))
+	assert.Equal(t, "", JSONTagName(typ.Field(1)))
+	assert.Equal(t, "", JSONTagName(typ.Field(2)))
+	assert.Equal(t, "", JSONTagName(typ.Field(3)))
+}
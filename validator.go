@@ -0,0 +1,46 @@
+package ponodo
+
+import (
+	"github.com/zerpto/ponodo/contracts"
+	"github.com/zerpto/ponodo/validation"
+)
+
+// ValidatorServiceProvider is the built-in service provider that creates
+// the request validator. It is registered by NewApp under the name
+// "validator".
+type ValidatorServiceProvider struct {
+}
+
+// Name returns the name the validator provider is registered under.
+func (p *ValidatorServiceProvider) Name() string {
+	return "validator"
+}
+
+// DependsOn returns the providers that must be registered before the validator.
+func (p *ValidatorServiceProvider) DependsOn() []string {
+	return nil
+}
+
+// Register creates the validator, stores it on the application and binds
+// it into the application container.
+func (p *ValidatorServiceProvider) Register(app contracts.AppContract) error {
+	v := validation.New()
+	app.SetValidator(v)
+	Instance(app, v)
+	return nil
+}
+
+// Boot is a no-op for the validator provider.
+func (p *ValidatorServiceProvider) Boot(app contracts.AppContract) error {
+	return nil
+}
+
+// Shutdown is a no-op for the validator provider.
+func (p *ValidatorServiceProvider) Shutdown(app contracts.AppContract) error {
+	return nil
+}
+
+// NewValidatorServiceProvider creates the built-in validator service provider.
+func NewValidatorServiceProvider() contracts.ServiceProviderContract {
+	return &ValidatorServiceProvider{}
+}